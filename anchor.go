@@ -0,0 +1,55 @@
+package winman
+
+// Anchor defines how a window keeps its place when the work area changes size,
+// for example when the terminal is resized
+type Anchor int16
+
+// Different anchors. AnchorRight and AnchorBottom can be combined
+const (
+	AnchorTopLeft      Anchor = 0      // the window keeps its coordinates
+	AnchorRight        Anchor = 1 << 0 // the window keeps its distance to the right edge
+	AnchorBottom       Anchor = 1 << 1 // the window keeps its distance to the bottom edge
+	AnchorProportional Anchor = 1 << 2 // the window keeps its relative position within the work area
+)
+
+// getAnchor returns the anchor of the given window
+func getAnchor(window Window) Anchor {
+	if base := getBase(window); base != nil {
+		return base.anchor
+	}
+	return AnchorTopLeft
+}
+
+// preferredRect returns where the window should be placed within the given work area,
+// according to the geometry last requested for it and its anchor
+func (wm *Manager) preferredRect(window Window, area Rect) Rect {
+	state := wm.state(window)
+	rect, from := state.preferred, state.preferredArea
+	anchor := getAnchor(window)
+	switch {
+	case anchor&AnchorProportional != 0:
+		if from.W > 0 {
+			rect.X = area.X + (rect.X-from.X)*area.W/from.W
+		}
+		if from.H > 0 {
+			rect.Y = area.Y + (rect.Y-from.Y)*area.H/from.H
+		}
+	default:
+		if anchor&AnchorRight != 0 {
+			rect.X = area.X + area.W - (from.X + from.W - rect.X)
+		}
+		if anchor&AnchorBottom != 0 {
+			rect.Y = area.Y + area.H - (from.Y + from.H - rect.Y)
+		}
+	}
+	return rect
+}
+
+// PreferredRect returns the geometry last requested for the given window, either by the
+// application or by the user. The window manager may shrink or move a window to fit
+// the work area, but it brings it back to its preferred geometry when there is room again
+func (wm *Manager) PreferredRect(window Window) Rect {
+	wm.lock()
+	defer wm.unlock()
+	return wm.preferredRect(window, wm.desktopRect(wm.workArea))
+}
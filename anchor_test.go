@@ -0,0 +1,59 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPreferredGeometry(t *testing.T) {
+	wm := winman.NewWindowManager()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 24)
+	screen.Init()
+	resize := func(w, h int) {
+		wm.SetRect(0, 0, w, h)
+		wm.Draw(screen)
+	}
+
+	free := wm.NewWindow().Show()
+	free.SetRect(50, 10, 20, 8)
+	right := wm.NewWindow().Show().SetAnchor(winman.AnchorRight | winman.AnchorBottom)
+	right.SetRect(60, 20, 10, 4)
+	proportional := wm.NewWindow().Show().SetAnchor(winman.AnchorProportional)
+	proportional.SetRect(40, 12, 10, 4)
+	resize(80, 24)
+
+	// shrinking the terminal squeezes the windows in
+	resize(40, 12)
+	if rect := winman.NewRect(free.GetRect()); rect != (Rect{20, 4, 20, 8}) {
+		t.Fatalf("Expected the window to be moved into the work area, got %s", rect)
+	}
+	if rect := winman.NewRect(right.GetRect()); rect != (Rect{20, 8, 10, 4}) {
+		t.Fatalf("Expected the anchored window to keep its distance to the bottom right corner, got %s", rect)
+	}
+	if rect := winman.NewRect(proportional.GetRect()); rect != (Rect{20, 6, 10, 4}) {
+		t.Fatalf("Expected the window to keep its relative position, got %s", rect)
+	}
+	if rect := wm.PreferredRect(free); rect != (Rect{50, 10, 20, 8}) {
+		t.Fatalf("Expected the preferred rect to be kept, got %s", rect)
+	}
+
+	// growing it back brings the windows back where they were
+	resize(80, 24)
+	if rect := winman.NewRect(free.GetRect()); rect != (Rect{50, 10, 20, 8}) {
+		t.Fatalf("Expected the window to return to its preferred rect, got %s", rect)
+	}
+	if rect := winman.NewRect(right.GetRect()); rect != (Rect{60, 20, 10, 4}) {
+		t.Fatalf("Expected the anchored window to return to its preferred rect, got %s", rect)
+	}
+
+	// moving a window while the terminal is small makes that its preferred rect
+	resize(40, 12)
+	free.SetRect(5, 2, 10, 5)
+	resize(80, 24)
+	if rect := winman.NewRect(free.GetRect()); rect != (Rect{5, 2, 10, 5}) {
+		t.Fatalf("Expected the window to stay where it was moved, got %s", rect)
+	}
+}
@@ -0,0 +1,98 @@
+package winman
+
+// arrangeable returns the windows that arrangement commands act upon: those on screen
+// that are neither modal nor maximized, in the order of the given stack
+func arrangeable(stack Stack) []Window {
+	var windows []Window
+	for _, wndItem := range stack {
+		window := wndItem.(Window)
+		if onScreen(window) && !window.IsModal() && !window.IsMaximized() {
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// arrange places the given windows on the given rects as a single step that can be undone
+func (wm *Manager) arrange(windows []Window, rects []Rect) {
+	step := make([]geometry, len(windows))
+	for i, window := range windows {
+		step[i] = wm.geometryOf(window)
+		wm.state(window).snap = SnapNone
+		window.SetRect(rects[i].Rect())
+	}
+	wm.pushUndo(step)
+}
+
+// Cascade stacks all visible windows that are neither modal nor maximized from the
+// top left corner of the visible work area, each one a bit below and to the right of the one
+// beneath it, keeping their z order and their size as far as the work area allows.
+// The change can be undone with Undo
+func (wm *Manager) Cascade() *Manager {
+	wm.lock()
+	defer wm.unlock()
+	area := wm.visibleRect(wm.layoutDocks())
+	// cascade following the z order, so the topmost window ends up in front
+	windows := arrangeable(wm.windows)
+	rects := make([]Rect, len(windows))
+	index := 0
+	for i, window := range windows {
+		_, _, w, h := window.GetRect()
+		x := area.X + index*cascadeOffsetX
+		y := area.Y + index*cascadeOffsetY
+		if index > 0 && (x+w > area.X+area.W || y+h > area.Y+area.H) {
+			index = 0
+			x, y = area.X, area.Y
+		}
+		rects[i] = Rect{X: x, Y: y, W: min(w, area.X+area.W-x), H: min(h, area.Y+area.H-y)}
+		index++
+	}
+	wm.arrange(windows, rects)
+	return wm
+}
+
+// TileHorizontally arranges all visible windows that are neither modal nor maximized
+// in horizontal strips, one above another, each taking the entire width of the work area.
+// The change can be undone with Undo
+func (wm *Manager) TileHorizontally() *Manager {
+	wm.lock()
+	defer wm.unlock()
+	area := wm.visibleRect(wm.layoutDocks())
+	windows := arrangeable(wm.order)
+	rects := make([]Rect, 0, len(windows))
+	y := area.Y
+	for _, h := range split(area.H, len(windows)) {
+		rects = append(rects, NewRect(area.X, y, area.W, h))
+		y += h
+	}
+	wm.arrange(windows, rects)
+	return wm
+}
+
+// TileVertically arranges all visible windows that are neither modal nor maximized
+// in vertical columns, side by side, each taking the entire height of the work area.
+// The change can be undone with Undo
+func (wm *Manager) TileVertically() *Manager {
+	wm.lock()
+	defer wm.unlock()
+	area := wm.visibleRect(wm.layoutDocks())
+	windows := arrangeable(wm.order)
+	rects := make([]Rect, 0, len(windows))
+	x := area.X
+	for _, w := range split(area.W, len(windows)) {
+		rects = append(rects, NewRect(x, area.Y, w, area.H))
+		x += w
+	}
+	wm.arrange(windows, rects)
+	return wm
+}
+
+// TileGrid arranges all visible windows that are neither modal nor maximized in a grid,
+// the same way GridLayout does. The change can be undone with Undo
+func (wm *Manager) TileGrid() *Manager {
+	wm.lock()
+	defer wm.unlock()
+	windows := arrangeable(wm.order)
+	wm.arrange(windows, GridLayout{}.Arrange(wm.visibleRect(wm.layoutDocks()), len(windows)))
+	return wm
+}
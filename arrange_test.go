@@ -0,0 +1,83 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestArrange(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+
+	initial := []Rect{{1, 1, 10, 5}, {5, 5, 10, 5}, {20, 10, 10, 5}}
+	var windows []*winman.WindowBase
+	for _, rect := range initial {
+		wnd := wm.NewWindow().Show()
+		wnd.SetRect(rect.Rect())
+		windows = append(windows, wnd)
+	}
+	// modal, maximized and hidden windows are not arranged
+	wm.NewWindow().Show().SetModal(true)
+	wm.NewWindow().Show().Maximize()
+	wm.NewWindow()
+	wm.Draw(screen)
+
+	assertRects := func(name string, expected []Rect) {
+		t.Helper()
+		for i, wnd := range windows {
+			if rect := winman.NewRect(wnd.GetRect()); rect != expected[i] {
+				t.Fatalf("%s: Expected window #%d at %s, got %s", name, i, expected[i], rect)
+			}
+		}
+	}
+
+	commands := []struct {
+		name     string
+		command  func() *winman.Manager
+		expected []Rect
+	}{
+		{"cascade", wm.Cascade, []Rect{{0, 0, 10, 5}, {2, 1, 10, 5}, {4, 2, 10, 5}}},
+		{"tile horizontally", wm.TileHorizontally, []Rect{{0, 0, 40, 7}, {0, 7, 40, 7}, {0, 14, 40, 6}}},
+		{"tile vertically", wm.TileVertically, []Rect{{0, 0, 14, 20}, {14, 0, 13, 20}, {27, 0, 13, 20}}},
+		{"tile grid", wm.TileGrid, []Rect{{0, 0, 20, 10}, {20, 0, 20, 10}, {0, 10, 40, 10}}},
+	}
+	for _, c := range commands {
+		c.command()
+		wm.Draw(screen)
+		assertRects(c.name, c.expected)
+	}
+
+	// commands and user moves share the same undo history
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	handler := wm.MouseHandler()
+	windows[0].SetDraggable(true)
+	wm.SetZ(windows[0], winman.WindowZTop)
+	handler(tview.MouseLeftDown, tcell.NewEventMouse(5, 0, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseMove, tcell.NewEventMouse(5, 2, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseLeftUp, tcell.NewEventMouse(5, 2, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if rect := winman.NewRect(windows[0].GetRect()); rect != (Rect{0, 2, 20, 10}) {
+		t.Fatalf("Expected window #0 to be dragged, got %s", rect)
+	}
+	if !wm.Undo() {
+		t.Fatal("Expected the drag to be undone")
+	}
+	assertRects("undo drag", commands[3].expected)
+	for i := len(commands) - 2; i >= 0; i-- {
+		if !wm.Undo() {
+			t.Fatalf("Expected %s to be undone", commands[i+1].name)
+		}
+		assertRects("undo "+commands[i+1].name, commands[i].expected)
+	}
+	wm.Undo()
+	assertRects("undo cascade", initial)
+	if wm.CanUndo() || wm.Undo() {
+		t.Fatal("Expected nothing else to undo")
+	}
+}
@@ -0,0 +1,85 @@
+package winman
+
+import "github.com/gdamore/tcell/v2"
+
+// StyleFilter transforms the style of content drawn on a screen
+type StyleFilter func(style tcell.Style) tcell.Style
+
+// StyleFilterScreen implements tcell.Screen and passes the style of all content
+// set on it through a style filter
+type StyleFilterScreen struct {
+	tcell.Screen
+	filter StyleFilter
+}
+
+// NewStyleFilterScreen creates a new screen that draws on the given screen
+// with styles transformed by the given filter
+func NewStyleFilterScreen(screen tcell.Screen, filter StyleFilter) *StyleFilterScreen {
+	return &StyleFilterScreen{
+		Screen: screen,
+		filter: filter,
+	}
+}
+
+// SetContent implements tcell.Screen.SetContent
+func (fs *StyleFilterScreen) SetContent(x int, y int, mainc rune, combc []rune, style tcell.Style) {
+	fs.Screen.SetContent(x, y, mainc, combc, fs.filter(style))
+}
+
+// SetCell implements tcell.Screen.SetCell
+func (fs *StyleFilterScreen) SetCell(x int, y int, style tcell.Style, ch ...rune) {
+	fs.Screen.SetCell(x, y, fs.filter(style), ch...)
+}
+
+// Fill implements tcell.Screen.Fill
+func (fs *StyleFilterScreen) Fill(ch rune, style tcell.Style) {
+	fs.Screen.Fill(ch, fs.filter(style))
+}
+
+// DimStyle is a style filter that dims the foreground
+func DimStyle(style tcell.Style) tcell.Style {
+	return style.Dim(true)
+}
+
+// gray returns the shade of gray with the same luminance as the given color.
+// Default and invalid colors are kept as they are
+func gray(color tcell.Color) tcell.Color {
+	if color == tcell.ColorDefault || !color.Valid() {
+		return color
+	}
+	r, g, b := color.RGB()
+	l := (299*r + 587*g + 114*b) / 1000
+	return tcell.NewRGBColor(l, l, l)
+}
+
+// DesaturateStyle is a style filter that turns colors into shades of gray
+func DesaturateStyle(style tcell.Style) tcell.Style {
+	fg, bg, _ := style.Decompose()
+	return style.Foreground(gray(fg)).Background(gray(bg))
+}
+
+// OverlayStyle returns a style filter that draws content with the given colors,
+// as if it was seen through a tinted overlay
+func OverlayStyle(foreground, background tcell.Color) StyleFilter {
+	return func(style tcell.Style) tcell.Style {
+		return style.Foreground(foreground).Background(background)
+	}
+}
+
+// SetModalBackdrop sets the style filter used to draw what is blocked by a modal window,
+// for example DimStyle, so users can see that it does not accept input.
+// A modal window without a parent dims the desktop below it, while a modal window
+// with a parent only dims its parent. The default, nil, draws blocked windows as usual.
+func (wm *Manager) SetModalBackdrop(filter StyleFilter) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.modalBackdrop = filter
+	return wm
+}
+
+// GetModalBackdrop returns the style filter used to draw what is blocked by a modal window
+func (wm *Manager) GetModalBackdrop() StyleFilter {
+	wm.lock()
+	defer wm.unlock()
+	return wm.modalBackdrop
+}
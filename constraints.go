@@ -0,0 +1,75 @@
+package winman
+
+// SizeConstraints limits the size a window can take when it is resized by the user,
+// tiled by a layout or clamped to the work area by the window manager.
+// Zero values mean no constraint.
+type SizeConstraints struct {
+	MinWidth    int     // minimum width. If zero, MinWindowWidth applies
+	MinHeight   int     // minimum height. If zero, MinWindowHeight applies
+	MaxWidth    int     // maximum width
+	MaxHeight   int     // maximum height
+	AspectRatio float64 // ratio of width to height, measured in cells
+	StepWidth   int     // width grows and shrinks in increments of this many cells, counted from the minimum width
+	StepHeight  int     // height grows and shrinks in increments of this many cells, counted from the minimum height
+}
+
+// Constrain returns the size closest to the given one that satisfies the constraints.
+// If an aspect ratio is set, the size is reduced to fit within the given one.
+func (c SizeConstraints) Constrain(w, h int) (int, int) {
+	return c.constrain(w, h, EdgeNone)
+}
+
+// constrain returns the size closest to the given one that satisfies the constraints.
+// The edge being dragged decides which dimension drives the aspect ratio:
+// when resizing sideways the height follows the width, and the other way around.
+func (c SizeConstraints) constrain(w, h int, edge WindowEdge) (int, int) {
+	minW, minH := c.MinWidth, c.MinHeight
+	if minW <= 0 {
+		minW = MinWindowWidth
+	}
+	if minH <= 0 {
+		minH = MinWindowHeight
+	}
+
+	if c.AspectRatio > 0 {
+		switch {
+		case edge == EdgeLeft || edge == EdgeRight:
+			h = int(float64(w)/c.AspectRatio + 0.5)
+		case edge == EdgeTop || edge == EdgeBottom:
+			w = int(float64(h)*c.AspectRatio + 0.5)
+		case int(float64(w)/c.AspectRatio+0.5) <= h:
+			h = int(float64(w)/c.AspectRatio + 0.5)
+		default:
+			w = int(float64(h)*c.AspectRatio + 0.5)
+		}
+	}
+
+	if c.StepWidth > 1 && w > minW {
+		w = minW + (w-minW)/c.StepWidth*c.StepWidth
+	}
+	if c.StepHeight > 1 && h > minH {
+		h = minH + (h-minH)/c.StepHeight*c.StepHeight
+	}
+
+	if c.MaxWidth > 0 && w > c.MaxWidth {
+		w = c.MaxWidth
+	}
+	if c.MaxHeight > 0 && h > c.MaxHeight {
+		h = c.MaxHeight
+	}
+	if w < minW {
+		w = minW
+	}
+	if h < minH {
+		h = minH
+	}
+	return w, h
+}
+
+// sizeConstraints returns the size constraints of the given window
+func sizeConstraints(window Window) SizeConstraints {
+	if base := getBase(window); base != nil {
+		return base.constraints
+	}
+	return SizeConstraints{}
+}
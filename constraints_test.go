@@ -0,0 +1,87 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestSizeConstraints(t *testing.T) {
+	tests := []struct {
+		constraints winman.SizeConstraints
+		w, h        int
+		ew, eh      int
+	}{
+		{winman.SizeConstraints{}, 1, 1, winman.MinWindowWidth, winman.MinWindowHeight},
+		{winman.SizeConstraints{MinWidth: 10, MinHeight: 5}, 8, 8, 10, 8},
+		{winman.SizeConstraints{MaxWidth: 20, MaxHeight: 10}, 30, 5, 20, 5},
+		{winman.SizeConstraints{AspectRatio: 2}, 30, 10, 20, 10},
+		{winman.SizeConstraints{AspectRatio: 2}, 10, 30, 10, 5},
+		{winman.SizeConstraints{MinWidth: 4, StepWidth: 3, StepHeight: 2}, 12, 8, 10, 7},
+	}
+	for i, test := range tests {
+		w, h := test.constraints.Constrain(test.w, test.h)
+		if w != test.ew || h != test.eh {
+			t.Fatalf("test #%d: Expected %dx%d, got %dx%d", i, test.ew, test.eh, w, h)
+		}
+	}
+}
+
+func TestWindowSizeConstraints(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	wnd := wm.NewWindow().Show().SetResizable(true).SetSizeConstraints(winman.SizeConstraints{
+		MinWidth:  6,
+		MaxWidth:  16,
+		MaxHeight: 12,
+	})
+	wnd.SetRect(10, 5, 2, 2)
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{10, 5, 6, 3}) {
+		t.Fatalf("Expected the window to be enlarged to its minimum size, got %s", rect)
+	}
+
+	// dragging the left edge beyond the maximum width keeps the right edge in place
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	handler := wm.MouseHandler()
+	handler(tview.MouseLeftDown, tcell.NewEventMouse(10, 6, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseMove, tcell.NewEventMouse(0, 6, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseLeftUp, tcell.NewEventMouse(0, 6, tcell.ButtonNone, tcell.ModNone), setFocus)
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{0, 5, 16, 3}) {
+		t.Fatalf("Expected the window to stop growing at its maximum width, got %s", rect)
+	}
+
+	// maximized and tiled windows respect their constraints
+	wnd.Maximize()
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{0, 0, 16, 12}) {
+		t.Fatalf("Expected the maximized window to keep its maximum size, got %s", rect)
+	}
+	wnd.Restore()
+	wm.SetLayout(winman.MonocleLayout{})
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{0, 0, 16, 12}) {
+		t.Fatalf("Expected the tiled window to keep its maximum size, got %s", rect)
+	}
+	wm.SetLayout(nil)
+
+	// resizing with the keyboard goes by steps
+	wnd.SetSizeConstraints(winman.SizeConstraints{StepWidth: 4})
+	wnd.SetRect(0, 0, 7, 5)
+	wm.Draw(screen)
+	wm.SetMoveResizeHotkey(winman.Hotkey{Key: tcell.KeyF7})
+	input := wm.InputHandler()
+	input(tcell.NewEventKey(tcell.KeyF7, 0, tcell.ModNone), setFocus)
+	input(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModShift), setFocus)
+	input(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{0, 0, 11, 5}) {
+		t.Fatalf("Expected the window to grow by one step, got %s", rect)
+	}
+}
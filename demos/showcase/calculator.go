@@ -91,7 +91,7 @@ func calculator() *winman.WindowBase {
 		Alignment: winman.ButtonLeft,
 		OnClick:   func() { wnd.Hide() },
 	})
-	wnd.SetRect(0, 0, 30, 15)
+	wnd.SetSize(30, 15)
 	wnd.Draggable = true
 	wnd.Resizable = true
 
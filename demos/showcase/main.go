@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/epiclabs-io/winman"
+	"github.com/epiclabs-io/winman/dialogs"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -13,13 +15,13 @@ func main() {
 
 	app := tview.NewApplication()
 	wm := winman.NewWindowManager()
-
-	quitMsgBox := MsgBox("Confirmation", "Really quit?", []string{"Yes", "No"}, func(clicked string) {
-		if clicked == "Yes" {
-			app.Stop()
-		}
-	})
-	wm.AddWindow(quitMsgBox)
+	wm.Dock(winman.NewTaskbar(wm), winman.EdgeBottom, 1).
+		SetSnapToEdges(true).
+		SetMoveResizeHotkey(winman.Hotkey{Key: tcell.KeyF7}).
+		SetSwitcherHotkey(winman.Hotkey{Key: tcell.KeyF2}).
+		SetTopResizeModifiers(tcell.ModAlt).
+		SetModalBackdrop(winman.DimStyle).
+		SetUpdateFunc(func(f func()) { app.QueueUpdateDraw(f) })
 
 	calc := calculator()
 	wm.AddWindow(calc)
@@ -32,6 +34,7 @@ func main() {
 
 	var createForm func(modal bool) *winman.WindowBase
 	var counter = 0
+	var openForms = 0
 
 	setZ := func(wnd *winman.WindowBase, newZ int) {
 		go app.QueueUpdateDraw(func() {
@@ -45,6 +48,7 @@ func main() {
 
 	createForm = func(modal bool) *winman.WindowBase {
 		counter++
+		openForms++
 		form := tview.NewForm()
 		window := winman.NewWindow().
 			SetRoot(form).
@@ -52,13 +56,27 @@ func main() {
 			SetDraggable(true).
 			SetModal(modal)
 
+		// closing the last form window asks whether to quit instead
+		window.SetEventHandler(func(event *winman.WindowEvent) {
+			if event.Type != winman.WindowClose {
+				return
+			}
+			if openForms > 1 {
+				openForms--
+				return
+			}
+			event.Cancel()
+			dialogs.NewConfirm("Confirmation", "Really quit?").
+				SetCallback(func(result dialogs.Result) {
+					if result.OK {
+						app.Stop()
+					}
+				}).
+				Open(wm, nil, setFocus)
+		})
+
 		quit := func() {
-			if wm.WindowCount() == 3 {
-				quitMsgBox.Show()
-				wm.Center(quitMsgBox)
-				setFocus(quitMsgBox)
-			} else {
-				wm.RemoveWindow(window)
+			if window.Close() {
 				setFocus(wm)
 			}
 		}
@@ -78,6 +96,10 @@ func main() {
 			AddCheckbox("Border", window.Draggable, func(checked bool) {
 				window.SetBorder(checked)
 			}).
+			AddDropDown("Layout", []string{"Floating", "Master/Stack", "Grid", "Monocle"}, 0, func(option string, optionIndex int) {
+				layouts := []winman.Layout{winman.FloatingLayout{}, winman.MasterStackLayout{}, winman.GridLayout{}, winman.MonocleLayout{}}
+				wm.SetLayout(layouts[optionIndex])
+			}).
 			AddInputField("Z-Index", "", 20, func(text string, char rune) bool {
 				return char >= '0' && char <= '9'
 			}, nil).
@@ -102,6 +124,18 @@ func main() {
 				wm.Center(calc)
 				setFocus(calc)
 			}).
+			AddButton("Move/Size", func() {
+				wm.BeginMoveResize(window)
+			}).
+			AddButton("Cascade", func() {
+				wm.Cascade()
+			}).
+			AddButton("Tile", func() {
+				wm.TileGrid()
+			}).
+			AddButton("Undo", func() {
+				wm.Undo()
+			}).
 			AddButton("Close", quit)
 
 		title := fmt.Sprintf("Window%d", counter)
@@ -128,6 +162,11 @@ func main() {
 			},
 		}
 		window.AddButton(maxMinButton)
+		window.AddButton(&winman.Button{
+			Symbol:    '_',
+			Alignment: winman.ButtonRight,
+			OnClick:   func() { window.Minimize() },
+		})
 		wm.AddWindow(window)
 		return window
 	}
@@ -0,0 +1,175 @@
+package winman
+
+import "github.com/gdamore/tcell/v2"
+
+// Distance the viewport is panned with the keyboard or the mouse wheel
+const (
+	PanStepX = 4
+	PanStepY = 2
+)
+
+// SetDesktopSize sets the size of the desktop, which can be larger than the work area.
+// Window coordinates are desktop coordinates: the window manager shows the part
+// of the desktop under the viewport, which the user can pan around.
+// Sizes smaller than the work area, such as zero, make the desktop as large as the work area,
+// which is the default.
+func (wm *Manager) SetDesktopSize(width, height int) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.desktopWidth, wm.desktopHeight = width, height
+	wm.setViewport(wm.layoutDocks(), wm.viewportX, wm.viewportY)
+	return wm
+}
+
+// GetDesktopSize returns the size of the desktop, which is at least as large as the work area
+func (wm *Manager) GetDesktopSize() (int, int) {
+	wm.lock()
+	defer wm.unlock()
+	desktop := wm.desktopRect(wm.layoutDocks())
+	return desktop.W, desktop.H
+}
+
+// SetViewport pans the viewport so the top left corner of the work area shows the given
+// offset of the desktop. The viewport is kept within the desktop
+func (wm *Manager) SetViewport(x, y int) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.setViewport(wm.layoutDocks(), x, y)
+	return wm
+}
+
+// GetViewport returns the offset of the desktop shown at the top left corner of the work area
+func (wm *Manager) GetViewport() (int, int) {
+	wm.lock()
+	defer wm.unlock()
+	return wm.viewportX, wm.viewportY
+}
+
+// SetPanModifiers sets the modifier keys that, combined with the arrow keys, pan the viewport
+// around the desktop. tcell.ModNone disables panning with the keyboard, which is the default
+func (wm *Manager) SetPanModifiers(modifiers tcell.ModMask) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.panModifiers = modifiers
+	return wm
+}
+
+// GetPanModifiers returns the modifier keys that pan the viewport with the arrow keys
+func (wm *Manager) GetPanModifiers() tcell.ModMask {
+	wm.lock()
+	defer wm.unlock()
+	return wm.panModifiers
+}
+
+// desktopRect returns the desktop, in desktop coordinates, for the given work area
+func (wm *Manager) desktopRect(area Rect) Rect {
+	return Rect{X: area.X, Y: area.Y, W: max(wm.desktopWidth, area.W), H: max(wm.desktopHeight, area.H)}
+}
+
+// visibleRect returns the part of the desktop shown in the given work area, in desktop coordinates
+func (wm *Manager) visibleRect(area Rect) Rect {
+	return Rect{X: area.X + wm.viewportX, Y: area.Y + wm.viewportY, W: area.W, H: area.H}
+}
+
+// setViewport moves the viewport to the given offset, keeping it within the desktop.
+// Returns true if the viewport moved
+func (wm *Manager) setViewport(area Rect, x, y int) bool {
+	desktop := wm.desktopRect(area)
+	x = max(0, min(x, desktop.W-area.W))
+	y = max(0, min(y, desktop.H-area.H))
+	if x == wm.viewportX && y == wm.viewportY {
+		return false
+	}
+	wm.viewportX, wm.viewportY = x, y
+	return true
+}
+
+// pan moves the viewport by the given distance. Returns true if the viewport moved
+func (wm *Manager) pan(dx, dy int) bool {
+	return wm.setViewport(wm.layoutDocks(), wm.viewportX+dx, wm.viewportY+dy)
+}
+
+// panKey pans the viewport if the given key is an arrow key combined with the pan modifiers
+func (wm *Manager) panKey(event *tcell.EventKey) bool {
+	if wm.panModifiers == tcell.ModNone || event.Modifiers() != wm.panModifiers {
+		return false
+	}
+	switch event.Key() {
+	case tcell.KeyUp:
+		wm.pan(0, -PanStepY)
+	case tcell.KeyDown:
+		wm.pan(0, PanStepY)
+	case tcell.KeyLeft:
+		wm.pan(-PanStepX, 0)
+	case tcell.KeyRight:
+		wm.pan(PanStepX, 0)
+	default:
+		return false
+	}
+	return true
+}
+
+// panAtEdge pans the viewport one cell towards the edge of the work area
+// the given screen coordinates are on, if any. Returns true if the viewport moved
+func (wm *Manager) panAtEdge(x, y int) bool {
+	area := wm.workArea
+	dx, dy := 0, 0
+	switch {
+	case x <= area.X:
+		dx = -1
+	case x >= area.X+area.W-1:
+		dx = 1
+	}
+	switch {
+	case y <= area.Y:
+		dy = -1
+	case y >= area.Y+area.H-1:
+		dy = 1
+	}
+	return (dx != 0 || dy != 0) && wm.pan(dx, dy)
+}
+
+// desktopScreen translates desktop coordinates to screen coordinates,
+// only allowing to draw within a region of the screen
+type desktopScreen struct {
+	*ClipRegion
+	offsetX, offsetY int
+}
+
+// newDesktopScreen returns a screen that draws the desktop shown in the given work area
+func (wm *Manager) newDesktopScreen(screen tcell.Screen, area Rect) *desktopScreen {
+	return &desktopScreen{
+		ClipRegion: NewClipRegion(screen, area.X, area.Y, area.W, area.H),
+		offsetX:    wm.viewportX,
+		offsetY:    wm.viewportY,
+	}
+}
+
+// SetContent implements tcell.Screen.SetContent
+func (ds *desktopScreen) SetContent(x int, y int, mainc rune, combc []rune, style tcell.Style) {
+	ds.ClipRegion.SetContent(x-ds.offsetX, y-ds.offsetY, mainc, combc, style)
+}
+
+// SetCell implements tcell.Screen.SetCell
+func (ds *desktopScreen) SetCell(x int, y int, style tcell.Style, ch ...rune) {
+	ds.ClipRegion.SetCell(x-ds.offsetX, y-ds.offsetY, style, ch...)
+}
+
+// GetContent implements tcell.Screen.GetContent
+func (ds *desktopScreen) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	return ds.ClipRegion.GetContent(x-ds.offsetX, y-ds.offsetY)
+}
+
+// ShowCursor implements tcell.Screen.ShowCursor
+func (ds *desktopScreen) ShowCursor(x int, y int) {
+	ds.ClipRegion.ShowCursor(x-ds.offsetX, y-ds.offsetY)
+}
+
+// toDesktop returns the given mouse event with its coordinates translated to desktop coordinates
+func (wm *Manager) toDesktop(event *tcell.EventMouse) *tcell.EventMouse {
+	if wm.viewportX == 0 && wm.viewportY == 0 {
+		return event
+	}
+	x, y := event.Position()
+	return tcell.NewEventMouse(x+wm.viewportX, y+wm.viewportY, event.Buttons(), event.Modifiers())
+}
@@ -0,0 +1,151 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestDesktopViewport(t *testing.T) {
+	wm := winman.NewWindowManager().SetDesktopSize(80, 40)
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	if w, h := wm.GetDesktopSize(); w != 80 || h != 40 {
+		t.Fatalf("Expected an 80x40 desktop, got %dx%d", w, h)
+	}
+
+	// windows can be placed anywhere on the desktop
+	root := NewBoringPrimitive('#')
+	wnd := wm.NewWindow().Show().SetRoot(root).SetDraggable(true)
+	wnd.SetRect(50, 25, 10, 5)
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{50, 25, 10, 5}) {
+		t.Fatalf("Expected the window to stay on the desktop outside the viewport, got %s", rect)
+	}
+
+	// the viewport is kept within the desktop
+	wm.SetViewport(100, 100)
+	if x, y := wm.GetViewport(); x != 40 || y != 20 {
+		t.Fatalf("Expected the viewport to be kept within the desktop, got %d,%d", x, y)
+	}
+
+	// windows are drawn and hit relative to the viewport
+	wm.SetViewport(40, 20)
+	screen.Clear()
+	wm.Draw(screen)
+	sm.Sync()
+	if sm.Char(11, 6) != "#" {
+		t.Fatalf("Expected the window to be drawn at its position within the viewport, got %q", sm.Char(11, 6))
+	}
+	handler := wm.MouseHandler()
+	handler(tview.MouseLeftClick, tcell.NewEventMouse(11, 6, tcell.Button1, tcell.ModNone), setFocus)
+	if root.clickCount != 1 {
+		t.Fatal("Expected the click to reach the window under the mouse")
+	}
+
+	// the mouse wheel on the background pans the viewport
+	handler(tview.MouseScrollUp, tcell.NewEventMouse(30, 15, tcell.ButtonNone, tcell.ModNone), setFocus)
+	handler(tview.MouseScrollLeft, tcell.NewEventMouse(30, 15, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if x, y := wm.GetViewport(); x != 40-winman.PanStepX || y != 20-winman.PanStepY {
+		t.Fatalf("Expected the mouse wheel to pan the viewport, got %d,%d", x, y)
+	}
+
+	// so do the arrow keys, once pan modifiers are set
+	input := wm.InputHandler()
+	input(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModAlt), setFocus)
+	wm.SetPanModifiers(tcell.ModAlt)
+	input(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModAlt), setFocus)
+	input(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModAlt), setFocus)
+	if x, y := wm.GetViewport(); x != 40 || y != 20 {
+		t.Fatalf("Expected the arrow keys to pan the viewport, got %d,%d", x, y)
+	}
+
+	// dragging a window against the edge pans the viewport
+	wm.Draw(screen)
+	handler(tview.MouseLeftDown, tcell.NewEventMouse(12, 5, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseMove, tcell.NewEventMouse(12, 0, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseMove, tcell.NewEventMouse(12, 0, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseLeftUp, tcell.NewEventMouse(12, 0, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if x, y := wm.GetViewport(); x != 40 || y != 18 {
+		t.Fatalf("Expected dragging against the top edge to pan the viewport, got %d,%d", x, y)
+	}
+	if rect := winman.NewRect(wnd.GetRect()); rect != (Rect{50, 18, 10, 5}) {
+		t.Fatalf("Expected the window to follow the mouse while panning, got %s", rect)
+	}
+}
+
+func TestMinimap(t *testing.T) {
+	wm := winman.NewWindowManager().SetDesktopSize(80, 40)
+	minimap := winman.NewMinimap(wm)
+	wm.Dock(minimap, winman.EdgeRight, 8)
+	wm.SetRect(0, 0, 48, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(48, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	wnd := wm.NewWindow().Show()
+	wnd.SetRect(60, 30, 20, 10)
+	wm.Draw(screen)
+	sm.Sync()
+
+	// the minimap is 8x20 for an 80x40 desktop: one cell every 10x2 desktop cells
+	if sm.Char(46, 16) != "░" {
+		t.Fatalf("Expected the window to be shown on the minimap, got %q", sm.Char(46, 16))
+	}
+	if sm.Char(40, 0) != string(tview.Borders.TopLeft) {
+		t.Fatalf("Expected the viewport outline on the minimap, got %q", sm.Char(40, 0))
+	}
+
+	// clicking on the minimap centers the viewport there
+	var focusedPrimitive tview.Primitive
+	wm.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(46, 16, tcell.Button1, tcell.ModNone), Focuser(&focusedPrimitive))
+	if x, y := wm.GetViewport(); x != 40 || y != 20 {
+		t.Fatalf("Expected the viewport to be moved to the bottom right of the desktop, got %d,%d", x, y)
+	}
+}
+
+func TestDesktopArrange(t *testing.T) {
+	wm := winman.NewWindowManager().SetDesktopSize(200, 100)
+	wm.SetRect(0, 0, 100, 50)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(100, 50)
+	screen.Init()
+
+	wndA := wm.NewWindow().Show()
+	wndA.SetRect(0, 0, 20, 10)
+	wndB := wm.NewWindow().Show()
+	wndB.SetRect(10, 10, 20, 10)
+	wm.SetViewport(100, 50)
+	wm.Draw(screen)
+
+	// windows are arranged within the visible part of the desktop
+	view := Rect{100, 50, 100, 50}
+	within := func(command string) {
+		for _, wnd := range []*winman.WindowBase{wndA, wndB} {
+			x, y, w, h := wnd.GetRect()
+			if x < view.X || y < view.Y || x+w > view.X+view.W || y+h > view.Y+view.H {
+				t.Fatalf("Expected %s to arrange windows within the viewport %s, got %s", command, view, winman.NewRect(x, y, w, h))
+			}
+		}
+	}
+	wm.Cascade()
+	if x, y, _, _ := wndA.GetRect(); x != 100 || y != 50 {
+		t.Fatalf("Expected the cascade to start at the top left corner of the viewport, got %d,%d", x, y)
+	}
+	within("Cascade")
+	wm.TileHorizontally()
+	within("TileHorizontally")
+	wm.TileVertically()
+	within("TileVertically")
+	wm.TileGrid()
+	within("TileGrid")
+}
@@ -0,0 +1,197 @@
+// Package dialogs implements standard modal dialogs for winman: message boxes,
+// confirmations, text and password input, list selection, forms, file pickers
+// and progress of long running tasks.
+//
+// Dialogs are opened on a window manager with Open, which centers them on the window
+// manager or on their parent window. A dialog with a parent only blocks its parent.
+// The result is reported both to the callback set with SetCallback and through the
+// channel returned by Open. Since callbacks run in the tview event loop, do not wait
+// on the channel from there, but from another goroutine.
+package dialogs
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/rivo/tview"
+)
+
+// size limits of dialogs, in cells
+const (
+	minWidth      = 30
+	maxWidth      = 60
+	maxListHeight = 10
+	fieldWidth    = 20 // room for the value of input fields
+)
+
+// Result is the answer given by the user to a dialog
+type Result struct {
+	OK     bool              // true if the user accepted the dialog, false if it was cancelled
+	Button string            // label of the button that closed the dialog, if any
+	Text   string            // text entered in an input or password dialog
+	Index  int               // index of the item chosen in a list dialog, or -1
+	Item   string            // item chosen in a list dialog
+	Values map[string]string // values of the fields of a form dialog, by label
+	Paths  []string          // paths chosen in a file picker
+	Err    error             // error returned by the task of a progress dialog
+}
+
+// Dialog is a modal window that asks something to the user and reports the answer
+type Dialog struct {
+	*winman.WindowBase
+	content  *tview.Flex                     // message on top, then the dialog controls
+	callback func(result Result)             // function called with the result
+	result   chan Result                     // channel the result is sent to
+	pending  Result                          // result reported when the dialog is hidden
+	done     bool                            // whether the result was reported
+	manager  *winman.Manager                 // window manager the dialog was opened on
+	parent   winman.Window                   // window the dialog belongs to, if any
+	setFocus func(p tview.Primitive)         // function used to give focus back when the dialog closes
+	handler  func(event *winman.WindowEvent) // event handler set by the application
+}
+
+// newDialog creates a dialog with the given title and message. Controls are added by each kind of dialog
+func newDialog(title, text string) *Dialog {
+	d := &Dialog{
+		WindowBase: winman.NewWindow(),
+		content:    tview.NewFlex().SetDirection(tview.FlexRow),
+	}
+	d.WindowBase.SetEventHandler(func(event *winman.WindowEvent) {
+		if d.handler != nil {
+			d.handler(event)
+		}
+		if event.Type == winman.WindowHide {
+			d.report()
+		}
+	})
+	d.SetRoot(d.content).
+		SetModal(true).
+		SetDraggable(true).
+		SetTitle(title)
+	if text != "" {
+		message := tview.NewTextView().SetText(text).SetTextAlign(tview.AlignCenter)
+		message.SetBorderPadding(1, 0, 1, 1)
+		d.content.AddItem(message, 0, 1, false)
+	}
+	return d
+}
+
+// textWidth returns the width of the longest line of the given texts
+func textWidth(texts ...string) int {
+	width := 0
+	for _, text := range texts {
+		for _, line := range strings.Split(text, "\n") {
+			if w := utf8.RuneCountInString(line); w > width {
+				width = w
+			}
+		}
+	}
+	return width
+}
+
+// dialogSize returns the width of a dialog showing the given message and controls of
+// the given width, and the number of lines the message takes
+func dialogSize(text string, contentWidth int) (width, lines int) {
+	width = contentWidth + 6
+	if w := textWidth(text) + 6; w > width {
+		width = w
+	}
+	if width < minWidth {
+		width = minWidth
+	}
+	if width > maxWidth {
+		width = maxWidth
+	}
+	if text != "" {
+		lines = len(tview.WordWrap(text, width-4)) + 1
+	}
+	return width, lines
+}
+
+// setSize sets the size of the dialog. Its position is set when it is opened
+func (d *Dialog) setSize(width, height int) {
+	d.SetSize(width, height)
+}
+
+// SetCallback sets the function called with the result once the dialog closes
+func (d *Dialog) SetCallback(callback func(result Result)) *Dialog {
+	d.callback = callback
+	return d
+}
+
+// SetEventHandler sets the function called on window events, as WindowBase.SetEventHandler does.
+// The dialog reports its result after the handler is called for the WindowHide event
+func (d *Dialog) SetEventHandler(handler func(event *winman.WindowEvent)) *Dialog {
+	d.handler = handler
+	return d
+}
+
+// Open adds the dialog to the given window manager, centered on the parent window if it
+// is not nil or else on the window manager, and gives it focus with the given function,
+// usually tview.Application.SetFocus. Returns the channel the result is sent to, a new one
+// each time the dialog is opened.
+// A dialog with a parent only blocks its parent, otherwise it blocks the whole window manager
+func (d *Dialog) Open(wm *winman.Manager, parent winman.Window, setFocus func(p tview.Primitive)) <-chan Result {
+	d.manager, d.parent, d.setFocus = wm, parent, setFocus
+	d.result, d.pending, d.done = make(chan Result, 1), Result{Index: -1}, false
+	d.SetParent(parent)
+	wm.AddWindow(d)
+	d.Show()
+	wm.Center(d)
+	if setFocus != nil {
+		setFocus(d)
+	}
+	return d.result
+}
+
+// finish closes the dialog with the given result, unless an event handler cancels closing it
+func (d *Dialog) finish(result Result) {
+	d.pending = result
+	d.Close()
+}
+
+// cancel closes the dialog as cancelled
+func (d *Dialog) cancel() {
+	d.finish(Result{Index: -1})
+}
+
+// report gives focus back and reports the result, once the dialog is hidden
+func (d *Dialog) report() {
+	if d.done || d.result == nil {
+		return
+	}
+	d.done = true
+	if d.setFocus != nil {
+		if d.parent != nil {
+			d.setFocus(d.parent)
+		} else if d.manager != nil {
+			d.setFocus(d.manager)
+		}
+	}
+	if d.callback != nil {
+		d.callback(d.pending)
+	}
+	d.result <- d.pending
+	close(d.result)
+}
+
+// newButtonForm creates a form for the controls of a dialog, with Esc cancelling the dialog
+func (d *Dialog) newButtonForm() *tview.Form {
+	form := tview.NewForm().SetButtonsAlign(tview.AlignCenter)
+	form.SetBorderPadding(1, 0, 1, 1)
+	form.SetCancelFunc(d.cancel)
+	return form
+}
+
+// formHeight returns the height of a form with the given number of fields and a row of buttons
+func formHeight(fields int) int {
+	return 1 + 2*fields + 1
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,212 @@
+package dialogs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/epiclabs-io/winman/dialogs"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// desktop is a window manager with a window, ready to open dialogs on
+type desktop struct {
+	wm       *winman.Manager
+	window   *winman.WindowBase
+	screen   tcell.SimulationScreen
+	focused  tview.Primitive
+	setFocus func(p tview.Primitive)
+}
+
+func newDesktop() *desktop {
+	d := &desktop{wm: winman.NewWindowManager()}
+	d.wm.SetRect(0, 0, 80, 40)
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	d.screen.SetSize(80, 40)
+	d.screen.Init()
+	d.window = d.wm.NewWindow().Show()
+	d.window.SetRect(10, 10, 40, 20)
+	d.setFocus = func(p tview.Primitive) {
+		if d.focused != nil {
+			d.focused.Blur()
+		}
+		d.focused = p
+		if p != nil {
+			p.Focus(d.setFocus)
+		}
+	}
+	d.setFocus(d.window)
+	d.wm.Draw(d.screen)
+	return d
+}
+
+// keys sends the given keys to the window manager, drawing after each one
+func (d *desktop) keys(keys ...interface{}) {
+	for _, key := range keys {
+		var event *tcell.EventKey
+		switch k := key.(type) {
+		case tcell.Key:
+			event = tcell.NewEventKey(k, 0, tcell.ModNone)
+		case string:
+			for _, r := range k {
+				d.wm.InputHandler()(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), d.setFocus)
+			}
+			continue
+		}
+		d.wm.InputHandler()(event, d.setFocus)
+		d.wm.Draw(d.screen)
+	}
+}
+
+// shows returns true if the given text is on the screen
+func (d *desktop) shows(text string) bool {
+	d.screen.Show()
+	cells, width, _ := d.screen.GetContents()
+	var b strings.Builder
+	for i, cell := range cells {
+		if i%width == 0 {
+			b.WriteRune('\n')
+		}
+		b.WriteString(string(cell.Runes))
+	}
+	return strings.Contains(b.String(), text)
+}
+
+// result returns the result of a dialog, failing if there is none yet
+func result(t *testing.T, results <-chan dialogs.Result) dialogs.Result {
+	t.Helper()
+	select {
+	case r := <-results:
+		return r
+	default:
+		t.Fatal("Expected the dialog to report a result")
+	}
+	return dialogs.Result{}
+}
+
+func TestConfirm(t *testing.T) {
+	d := newDesktop()
+	var called dialogs.Result
+	confirm := dialogs.NewConfirm("Quit", "Really quit?").SetCallback(func(r dialogs.Result) {
+		called = r
+	})
+	results := confirm.Open(d.wm, nil, d.setFocus)
+	d.wm.Draw(d.screen)
+
+	if !confirm.IsModal() || !confirm.HasFocus() || d.wm.WindowCount() != 2 {
+		t.Fatal("Expected the dialog to be open, modal and focused")
+	}
+	x, y, w, h := confirm.GetRect()
+	if x != (80-w)/2 || y != (40-h)/2 {
+		t.Fatalf("Expected the dialog to be centered on the window manager, got %d,%d", x, y)
+	}
+	if len(d.wm.ModalStack()) != 1 {
+		t.Fatal("Expected the dialog to block the window manager")
+	}
+
+	// Tab moves to the No button
+	d.keys(tcell.KeyTab, tcell.KeyEnter)
+	r := result(t, results)
+	if r.OK || r.Button != "No" || called.Button != "No" {
+		t.Fatalf("Expected No to be reported to the channel and the callback, got %+v and %+v", r, called)
+	}
+	if d.wm.WindowCount() != 1 || !d.window.HasFocus() {
+		t.Fatal("Expected the dialog to close and focus to go back")
+	}
+
+	// Esc cancels
+	results = dialogs.NewMessage("Info", "Done").Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyEscape)
+	if r := result(t, results); r.OK || r.Button != "" {
+		t.Fatalf("Expected the dialog to be cancelled, got %+v", r)
+	}
+
+	// dialogs can be opened again, reporting to a new channel
+	results = confirm.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyBacktab, tcell.KeyEnter)
+	if r := result(t, results); !r.OK || r.Button != "Yes" {
+		t.Fatalf("Expected Yes to be reported when the dialog is opened again, got %+v", r)
+	}
+}
+
+func TestInput(t *testing.T) {
+	d := newDesktop()
+	results := dialogs.NewInput("Name", "What is your name?", "Name", "").Open(d.wm, nil, d.setFocus)
+	d.keys("Ann", tcell.KeyEnter)
+	if r := result(t, results); !r.OK || r.Text != "Ann" {
+		t.Fatalf("Expected the entered text, got %+v", r)
+	}
+
+	results = dialogs.NewPassword("Login", "", "Password").Open(d.wm, nil, d.setFocus)
+	d.keys("secret", tcell.KeyEnter)
+	if r := result(t, results); !r.OK || r.Text != "secret" {
+		t.Fatalf("Expected the entered password, got %+v", r)
+	}
+}
+
+func TestList(t *testing.T) {
+	d := newDesktop()
+	results := dialogs.NewList("Color", "Pick a color", []string{"red", "green", "blue"}).Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyDown, tcell.KeyEnter)
+	if r := result(t, results); !r.OK || r.Index != 1 || r.Item != "green" {
+		t.Fatalf("Expected the second item to be chosen, got %+v", r)
+	}
+}
+
+func TestForm(t *testing.T) {
+	d := newDesktop()
+	form := dialogs.NewForm("Login", "",
+		dialogs.Field{Label: "User", Value: "root"},
+		dialogs.Field{Label: "Password", Password: true},
+		dialogs.Field{Label: "Role", Options: []string{"admin", "guest"}, Value: "guest"},
+	)
+	results := form.Open(d.wm, d.window, d.setFocus)
+	d.wm.Draw(d.screen)
+
+	// a dialog with a parent is centered on it and only blocks it
+	x, y, w, h := form.GetRect()
+	if x != 10+(40-w)/2 || y != 10+(20-h)/2 {
+		t.Fatalf("Expected the dialog to be centered on its parent, got %d,%d", x, y)
+	}
+	if form.GetParent() != d.window {
+		t.Fatal("Expected the dialog to belong to its parent")
+	}
+
+	d.keys(tcell.KeyTab, "1234", tcell.KeyTab, tcell.KeyTab, tcell.KeyEnter)
+	r := result(t, results)
+	if !r.OK || r.Values["User"] != "root" || r.Values["Password"] != "1234" || r.Values["Role"] != "guest" {
+		t.Fatalf("Expected the form values, got %+v", r)
+	}
+	if !d.window.HasFocus() {
+		t.Fatal("Expected focus to go back to the parent")
+	}
+}
+
+func TestDialogEventHandler(t *testing.T) {
+	d := newDesktop()
+	vetoes := 1
+	var events []winman.WindowEventType
+	message := dialogs.NewMessage("Info", "Done")
+	message.SetEventHandler(func(event *winman.WindowEvent) {
+		events = append(events, event.Type)
+		if event.Type == winman.WindowClose && vetoes > 0 {
+			vetoes--
+			event.Cancel()
+		}
+	})
+	results := message.Open(d.wm, nil, d.setFocus)
+
+	// the handler set by the application is kept, and can keep the dialog open
+	d.keys(tcell.KeyEscape)
+	if len(results) != 0 || d.wm.WindowCount() != 2 {
+		t.Fatal("Expected the event handler to keep the dialog open")
+	}
+	d.keys(tcell.KeyEscape)
+	if r := result(t, results); r.OK {
+		t.Fatalf("Expected the dialog to be cancelled, got %+v", r)
+	}
+	if len(events) == 0 || events[len(events)-1] != winman.WindowHide {
+		t.Fatalf("Expected the event handler to be called, got %v", events)
+	}
+}
@@ -0,0 +1,460 @@
+package dialogs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// size of file pickers, and limits of what they read to show previews and completions
+const (
+	filePickerWidth  = 76
+	filePickerHeight = 22
+	maxPreviewBytes  = 4096
+	maxCompletions   = 20
+)
+
+// parentEntry is the list entry that goes up to the parent directory
+const parentEntry = "../"
+
+// FilePicker is a dialog to choose files or directories. The current directory is listed
+// on the left and the highlighted entry is previewed on the right. Enter opens directories,
+// Backspace goes up to the parent directory, and with multi-select enabled, Space marks entries.
+// The path field on top accepts typed paths, offering completions.
+// The chosen paths are in the Paths field of the result
+type FilePicker struct {
+	*Dialog
+	dir         string            // directory being listed
+	filter      string            // glob patterns of files to list, separated by semicolons
+	showHidden  bool              // whether to list hidden files
+	multiSelect bool              // whether several entries can be chosen
+	directories bool              // whether directories are chosen instead of files
+	mustExist   bool              // whether typed paths must exist
+	entries     []os.FileInfo     // entries listed, after the parent entry if any
+	hasParent   bool              // whether the list starts with the parent entry
+	marked      map[string]bool   // paths marked with multi-select
+	path        *tview.InputField // path entry
+	list        *tview.List       // directory listing
+	preview     *tview.TextView   // preview of the highlighted entry
+	filterField *tview.InputField // glob filter entry
+	hidden      *tview.Checkbox   // hidden files toggle
+	ring        []tview.Primitive // controls in Tab order
+}
+
+// NewFilePicker creates a dialog to choose files, starting in the given directory,
+// or in the working directory if it is empty
+func NewFilePicker(title, dir string) *FilePicker {
+	return newFilePicker(title, dir, false)
+}
+
+// NewDirectoryPicker creates a dialog to choose directories, starting in the given directory,
+// or in the working directory if it is empty. Choosing without highlighting a directory
+// chooses the directory being listed
+func NewDirectoryPicker(title, dir string) *FilePicker {
+	return newFilePicker(title, dir, true)
+}
+
+// newFilePicker creates a file or directory picker
+func newFilePicker(title, dir string, directories bool) *FilePicker {
+	if dir == "" {
+		dir = "."
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	fp := &FilePicker{
+		Dialog:      newDialog(title, ""),
+		dir:         dir,
+		directories: directories,
+		mustExist:   true,
+		marked:      make(map[string]bool),
+		path:        tview.NewInputField().SetLabel("Path "),
+		list:        tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true),
+		preview:     tview.NewTextView(),
+		filterField: tview.NewInputField().SetLabel("Filter ").SetFieldWidth(12),
+		hidden:      tview.NewCheckbox().SetLabel("Hidden "),
+	}
+	fp.SetResizable(true)
+	fp.preview.SetBorder(true).SetTitle("Preview")
+	fp.list.SetBorder(true)
+
+	ok := tview.NewButton("OK").SetSelectedFunc(fp.accept)
+	cancel := tview.NewButton("Cancel").SetSelectedFunc(fp.cancel)
+	fp.ring = []tview.Primitive{fp.path, fp.list, fp.filterField, fp.hidden, ok, cancel}
+
+	// Tab and Backtab move between controls, Esc cancels
+	exit := func(p tview.Primitive) func(key tcell.Key) {
+		return func(key tcell.Key) {
+			switch key {
+			case tcell.KeyTab:
+				fp.next(p, 1)
+			case tcell.KeyBacktab:
+				fp.next(p, -1)
+			case tcell.KeyEscape:
+				fp.cancel()
+			}
+		}
+	}
+	fp.path.SetAutocompleteFunc(fp.complete).SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			fp.enterPath(fp.path.GetText())
+			return
+		}
+		exit(fp.path)(key)
+	})
+	fp.filterField.SetChangedFunc(func(text string) {
+		fp.filter = text
+		fp.load()
+	}).SetDoneFunc(exit(fp.filterField))
+	fp.hidden.SetChangedFunc(func(checked bool) {
+		fp.showHidden = checked
+		fp.load()
+	}).SetDoneFunc(exit(fp.hidden))
+	ok.SetExitFunc(exit(ok))
+	cancel.SetExitFunc(exit(cancel))
+
+	fp.list.SetChangedFunc(func(int, string, string, rune) {
+		fp.updatePreview()
+	})
+	fp.list.SetSelectedFunc(func(int, string, string, rune) {
+		fp.open()
+	})
+	fp.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape:
+			exit(fp.list)(event.Key())
+			return nil
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			fp.navigate(filepath.Dir(fp.dir))
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == ' ' {
+				fp.toggleMark()
+				return nil
+			}
+		}
+		return event
+	})
+
+	buttons := tview.NewFlex().
+		AddItem(fp.filterField, 0, 2, false).
+		AddItem(fp.hidden, 9, 0, false).
+		AddItem(ok, 6, 0, false).
+		AddItem(nil, 1, 0, false).
+		AddItem(cancel, 10, 0, false)
+	panes := tview.NewFlex().
+		AddItem(fp.list, 0, 1, true).
+		AddItem(fp.preview, 0, 1, false)
+	fp.content.SetBorderPadding(0, 0, 1, 1)
+	fp.content.AddItem(fp.path, 1, 0, false).
+		AddItem(panes, 0, 1, true).
+		AddItem(buttons, 1, 0, false)
+	fp.setSize(filePickerWidth, filePickerHeight)
+
+	fp.load()
+	return fp
+}
+
+// SetFilter sets glob patterns, like "*.go", that files must match to be listed.
+// Several patterns can be given separated by semicolons. Directories are always listed
+func (fp *FilePicker) SetFilter(filter string) *FilePicker {
+	fp.filter = filter
+	fp.filterField.SetText(filter)
+	fp.load()
+	return fp
+}
+
+// SetShowHidden sets whether hidden files, whose name starts with a dot, are listed
+func (fp *FilePicker) SetShowHidden(show bool) *FilePicker {
+	fp.showHidden = show
+	fp.hidden.SetChecked(show)
+	fp.load()
+	return fp
+}
+
+// SetMultiSelect sets whether several entries can be marked with Space and chosen at once
+func (fp *FilePicker) SetMultiSelect(multiSelect bool) *FilePicker {
+	fp.multiSelect = multiSelect
+	if !multiSelect {
+		fp.marked = make(map[string]bool)
+	}
+	fp.load()
+	return fp
+}
+
+// SetMustExist sets whether typed paths must exist to be chosen. It is true by default,
+// and can be disabled to pick the name of a file to save
+func (fp *FilePicker) SetMustExist(mustExist bool) *FilePicker {
+	fp.mustExist = mustExist
+	return fp
+}
+
+// GetDir returns the directory being listed
+func (fp *FilePicker) GetDir() string {
+	return fp.dir
+}
+
+// next gives focus to the control offset positions away from the given one in Tab order
+func (fp *FilePicker) next(from tview.Primitive, offset int) {
+	for i, p := range fp.ring {
+		if p == from && fp.setFocus != nil {
+			fp.setFocus(fp.ring[(i+offset+len(fp.ring))%len(fp.ring)])
+			return
+		}
+	}
+}
+
+// matches returns true if the given file name matches the filter
+func (fp *FilePicker) matches(name string) bool {
+	if strings.TrimSpace(fp.filter) == "" {
+		return true
+	}
+	for _, pattern := range strings.Split(fp.filter, ";") {
+		if ok, _ := filepath.Match(strings.TrimSpace(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// visible returns true if the given entry must be listed
+func (fp *FilePicker) visible(info os.FileInfo) bool {
+	if !fp.showHidden && strings.HasPrefix(info.Name(), ".") {
+		return false
+	}
+	if info.IsDir() {
+		return true
+	}
+	return !fp.directories && fp.matches(info.Name())
+}
+
+// load lists the current directory
+func (fp *FilePicker) load() {
+	infos, err := ioutil.ReadDir(fp.dir)
+	fp.entries = fp.entries[:0]
+	for _, info := range infos {
+		if fp.visible(info) {
+			fp.entries = append(fp.entries, info)
+		}
+	}
+	// directories go first
+	sort.SliceStable(fp.entries, func(a, b int) bool {
+		return fp.entries[a].IsDir() && !fp.entries[b].IsDir()
+	})
+
+	fp.list.Clear()
+	fp.hasParent = filepath.Dir(fp.dir) != fp.dir
+	if fp.hasParent {
+		fp.list.AddItem(parentEntry, "", 0, nil)
+	}
+	for _, info := range fp.entries {
+		fp.list.AddItem(fp.entryText(info), "", 0, nil)
+	}
+	fp.list.SetTitle(fp.dir)
+	fp.path.SetText(fp.dir)
+	if err != nil {
+		fp.preview.SetText(err.Error())
+		return
+	}
+	if fp.hasParent && len(fp.entries) > 0 {
+		fp.list.SetCurrentItem(1)
+	}
+	fp.updatePreview()
+}
+
+// entryText returns the text of the list entry of the given file
+func (fp *FilePicker) entryText(info os.FileInfo) string {
+	name := info.Name()
+	if info.IsDir() {
+		name += "/"
+	}
+	if !fp.multiSelect {
+		return name
+	}
+	if fp.marked[filepath.Join(fp.dir, info.Name())] {
+		return "[x] " + name
+	}
+	return "[ ] " + name
+}
+
+// current returns the entry highlighted in the list, or nil for the parent entry or an empty list
+func (fp *FilePicker) current() os.FileInfo {
+	i := fp.list.GetCurrentItem()
+	if fp.hasParent {
+		i--
+	}
+	if i < 0 || i >= len(fp.entries) {
+		return nil
+	}
+	return fp.entries[i]
+}
+
+// updatePreview shows the beginning of the highlighted file, or the entries of the highlighted directory
+func (fp *FilePicker) updatePreview() {
+	fp.preview.SetText("").ScrollToBeginning()
+	info := fp.current()
+	if info == nil {
+		return
+	}
+	path := filepath.Join(fp.dir, info.Name())
+	if info.IsDir() {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			fp.preview.SetText(err.Error())
+			return
+		}
+		var names []string
+		for _, entry := range infos {
+			if fp.visible(entry) {
+				name := entry.Name()
+				if entry.IsDir() {
+					name += "/"
+				}
+				names = append(names, name)
+			}
+		}
+		fp.preview.SetText(strings.Join(names, "\n"))
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fp.preview.SetText(err.Error())
+		return
+	}
+	defer file.Close()
+	data := make([]byte, maxPreviewBytes)
+	n, err := io.ReadFull(file, data)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		fp.preview.SetText(err.Error())
+		return
+	}
+	if bytes.IndexByte(data[:n], 0) != -1 {
+		fp.preview.SetText("(binary file)")
+		return
+	}
+	fp.preview.SetText(tview.Escape(string(data[:n])))
+}
+
+// navigate lists the given directory
+func (fp *FilePicker) navigate(dir string) {
+	fp.dir = dir
+	fp.load()
+}
+
+// open opens the highlighted directory or chooses the highlighted file
+func (fp *FilePicker) open() {
+	info := fp.current()
+	switch {
+	case info == nil && fp.hasParent:
+		fp.navigate(filepath.Dir(fp.dir))
+	case info != nil && info.IsDir():
+		fp.navigate(filepath.Join(fp.dir, info.Name()))
+	case info != nil:
+		fp.accept()
+	}
+}
+
+// toggleMark marks or unmarks the highlighted entry, with multi-select enabled
+func (fp *FilePicker) toggleMark() {
+	info := fp.current()
+	if !fp.multiSelect || info == nil || info.IsDir() != fp.directories {
+		return
+	}
+	path := filepath.Join(fp.dir, info.Name())
+	fp.marked[path] = !fp.marked[path]
+	if !fp.marked[path] {
+		delete(fp.marked, path)
+	}
+	fp.list.SetItemText(fp.list.GetCurrentItem(), fp.entryText(info), "")
+}
+
+// accept chooses the marked entries, or else the highlighted one
+func (fp *FilePicker) accept() {
+	if len(fp.marked) > 0 {
+		var paths []string
+		for path := range fp.marked {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		fp.choose(paths...)
+		return
+	}
+	info := fp.current()
+	switch {
+	case fp.directories && (info == nil || !info.IsDir()):
+		fp.choose(fp.dir)
+	case info == nil:
+	case info.IsDir() == fp.directories:
+		fp.choose(filepath.Join(fp.dir, info.Name()))
+	default:
+		fp.open()
+	}
+}
+
+// choose closes the dialog with the given paths as result
+func (fp *FilePicker) choose(paths ...string) {
+	fp.finish(Result{OK: true, Button: "OK", Index: -1, Paths: paths})
+}
+
+// enterPath lists a typed directory or chooses a typed file
+func (fp *FilePicker) enterPath(path string) {
+	if path == "" {
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(fp.dir, path)
+	}
+	path = filepath.Clean(path)
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir() && !(fp.directories && path == fp.dir):
+		fp.navigate(path)
+	case err == nil && info.IsDir() == fp.directories:
+		fp.choose(path)
+	case err != nil && !fp.mustExist:
+		fp.choose(path)
+	case err != nil:
+		fp.preview.SetText(err.Error())
+	}
+}
+
+// complete returns the paths that start with the given text
+func (fp *FilePicker) complete(text string) []string {
+	if text == "" || text == fp.dir {
+		return nil
+	}
+	// relative paths are relative to the current directory, as in enterPath
+	dir, prefix := filepath.Split(text)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(fp.dir, dir)
+	}
+	typed := filepath.Join(dir, prefix)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var entries []string
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name(), prefix) || !fp.visible(info) {
+			continue
+		}
+		entry := filepath.Join(dir, info.Name())
+		if info.IsDir() {
+			entry += string(filepath.Separator)
+		}
+		if entry != typed {
+			entries = append(entries, entry)
+		}
+		if len(entries) == maxCompletions {
+			break
+		}
+	}
+	return entries
+}
@@ -0,0 +1,122 @@
+package dialogs_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/epiclabs-io/winman/dialogs"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTree creates a directory with a few files to pick from
+func newTree(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "winman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"a.txt":       "first file",
+		"b.go":        "package b",
+		".hidden":     "secret",
+		"sub/c.txt":   "nested file",
+		"sub/d.txt":   "another nested file",
+		"other/e.txt": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFilePicker(t *testing.T) {
+	dir := newTree(t)
+	defer os.RemoveAll(dir)
+	d := newDesktop()
+
+	// the first entry after the parent directory is highlighted: other/, sub/, a.txt, b.go
+	picker := dialogs.NewFilePicker("Open", dir)
+	results := picker.Open(d.wm, nil, d.setFocus)
+	d.wm.Draw(d.screen)
+	d.keys(tcell.KeyDown, tcell.KeyDown)
+	if !d.shows("first file") {
+		t.Fatal("Expected the highlighted file to be previewed")
+	}
+	d.keys(tcell.KeyEnter)
+	r := result(t, results)
+	if !r.OK || len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "a.txt") {
+		t.Fatalf("Expected a.txt to be chosen, got %+v", r)
+	}
+
+	// Enter opens directories, and with multi-select Space marks files
+	picker = dialogs.NewFilePicker("Open", dir).SetMultiSelect(true)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyDown, tcell.KeyEnter)
+	if picker.GetDir() != filepath.Join(dir, "sub") {
+		t.Fatalf("Expected to enter the sub directory, got %s", picker.GetDir())
+	}
+	d.keys(" ", tcell.KeyDown, " ", tcell.KeyEnter)
+	r = result(t, results)
+	if len(r.Paths) != 2 || r.Paths[0] != filepath.Join(dir, "sub", "c.txt") || r.Paths[1] != filepath.Join(dir, "sub", "d.txt") {
+		t.Fatalf("Expected both marked files to be chosen, got %+v", r)
+	}
+
+	// turning multi-select off forgets the marked files
+	picker = dialogs.NewFilePicker("Open", dir).SetMultiSelect(true)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyDown, tcell.KeyDown, " ")
+	picker.SetMultiSelect(false)
+	d.keys(tcell.KeyDown, tcell.KeyDown, tcell.KeyDown, tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "b.go") {
+		t.Fatalf("Expected only the highlighted file to be chosen, got %+v", r)
+	}
+
+	// typed relative paths are completed within the listed directory
+	picker = dialogs.NewFilePicker("Open", dir)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyBacktab, tcell.KeyCtrlU, "sub/c", tcell.KeyTab, tcell.KeyEnter, tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "sub", "c.txt") {
+		t.Fatalf("Expected the completed path to be chosen, got %+v", r)
+	}
+
+	// filters and hidden files
+	picker = dialogs.NewFilePicker("Open", dir).SetFilter("*.go").SetShowHidden(true)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyEnd, tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "b.go") {
+		t.Fatalf("Expected b.go to be the last file listed, got %+v", r)
+	}
+	picker = dialogs.NewFilePicker("Open", dir).SetShowHidden(true)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyDown, tcell.KeyDown, tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, ".hidden") {
+		t.Fatalf("Expected the hidden file to be listed, got %+v", r)
+	}
+
+	// typed paths that do not exist can be chosen to save files, and Esc cancels
+	picker = dialogs.NewFilePicker("Save", dir).SetMustExist(false)
+	results = picker.Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyBacktab, tcell.KeyCtrlU, "new.txt", tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "new.txt") {
+		t.Fatalf("Expected the typed path to be chosen, got %+v", r)
+	}
+	results = dialogs.NewFilePicker("Open", dir).Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyEscape)
+	if r := result(t, results); r.OK || r.Paths != nil {
+		t.Fatalf("Expected the picker to be cancelled, got %+v", r)
+	}
+
+	// directory pickers choose the directory being listed
+	results = dialogs.NewDirectoryPicker("Folder", dir).Open(d.wm, nil, d.setFocus)
+	d.keys(tcell.KeyDown, tcell.KeyEnter, tcell.KeyTab, tcell.KeyTab, tcell.KeyTab, tcell.KeyEnter)
+	if r := result(t, results); len(r.Paths) != 1 || r.Paths[0] != filepath.Join(dir, "sub") {
+		t.Fatalf("Expected the sub directory to be chosen, got %+v", r)
+	}
+}
@@ -0,0 +1,55 @@
+package dialogs
+
+import "github.com/rivo/tview"
+
+// Field describes a field of a form dialog
+type Field struct {
+	Label    string   // label of the field, also its key in the result values
+	Value    string   // initial value
+	Password bool     // whether the value is masked while typing
+	Options  []string // options to choose from. If set, the field is a drop down
+}
+
+// NewForm creates a dialog asking the user to fill in the given fields,
+// with OK and Cancel buttons. The values of the fields are in the Values field
+// of the result, by label
+func NewForm(title, text string, fields ...Field) *Dialog {
+	d := newDialog(title, text)
+	form := d.newButtonForm()
+	labelWidth := 0
+	for _, field := range fields {
+		switch {
+		case field.Options != nil:
+			initial := 0
+			for i, option := range field.Options {
+				if option == field.Value {
+					initial = i
+				}
+			}
+			form.AddDropDown(field.Label, field.Options, initial, nil)
+		case field.Password:
+			form.AddPasswordField(field.Label, field.Value, 0, '*', nil)
+		default:
+			form.AddInputField(field.Label, field.Value, 0, nil, nil)
+		}
+		labelWidth = max(labelWidth, textWidth(field.Label))
+	}
+	form.AddButton("OK", func() {
+		values := make(map[string]string)
+		for _, field := range fields {
+			switch item := form.GetFormItemByLabel(field.Label).(type) {
+			case *tview.InputField:
+				values[field.Label] = item.GetText()
+			case *tview.DropDown:
+				_, values[field.Label] = item.GetCurrentOption()
+			}
+		}
+		d.finish(Result{OK: true, Button: "OK", Index: -1, Values: values})
+	})
+	form.AddButton("Cancel", d.cancel)
+
+	d.content.AddItem(form, formHeight(len(fields)), 0, true)
+	width, lines := dialogSize(text, max(textWidth(title), labelWidth+1+fieldWidth))
+	d.setSize(width, 2+lines+formHeight(len(fields)))
+	return d
+}
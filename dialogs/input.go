@@ -0,0 +1,41 @@
+package dialogs
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// NewInput creates a dialog asking the user to enter a line of text, starting with the given value.
+// Enter or the OK button accept it. The entered text is in the Text field of the result
+func NewInput(title, text, label, value string) *Dialog {
+	field := tview.NewInputField().SetLabel(label).SetText(value)
+	return newInput(title, text, field)
+}
+
+// NewPassword creates a dialog asking the user to enter a password, which is masked while typing.
+// Enter or the OK button accept it. The entered password is in the Text field of the result
+func NewPassword(title, text, label string) *Dialog {
+	field := tview.NewInputField().SetLabel(label).SetMaskCharacter('*')
+	return newInput(title, text, field)
+}
+
+// newInput creates a dialog with the given input field and OK and Cancel buttons
+func newInput(title, text string, field *tview.InputField) *Dialog {
+	d := newDialog(title, text)
+	accept := func() {
+		d.finish(Result{OK: true, Button: "OK", Text: field.GetText(), Index: -1})
+	}
+	field.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			accept()
+		}
+	})
+	form := d.newButtonForm().
+		AddFormItem(field).
+		AddButton("OK", accept).
+		AddButton("Cancel", d.cancel)
+	d.content.AddItem(form, formHeight(1), 0, true)
+	width, lines := dialogSize(text, max(textWidth(title), textWidth(field.GetLabel())+fieldWidth))
+	d.setSize(width, 2+lines+formHeight(1))
+	return d
+}
@@ -0,0 +1,28 @@
+package dialogs
+
+import "github.com/rivo/tview"
+
+// NewList creates a dialog asking the user to choose one of the given items.
+// Enter or a click chooses the current item and Esc cancels the dialog.
+// The chosen item and its index are in the Item and Index fields of the result
+func NewList(title, text string, items []string) *Dialog {
+	d := newDialog(title, text)
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorderPadding(1, 1, 1, 1)
+	for _, item := range items {
+		list.AddItem(item, "", 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, item string, _ string, _ rune) {
+		d.finish(Result{OK: true, Index: index, Item: item})
+	})
+	list.SetDoneFunc(d.cancel)
+
+	height := len(items)
+	if height > maxListHeight {
+		height = maxListHeight
+	}
+	d.content.AddItem(list, height+2, 0, true)
+	width, lines := dialogSize(text, textWidth(append([]string{title}, items...)...))
+	d.setSize(width, 2+lines+height+2)
+	return d
+}
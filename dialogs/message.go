@@ -0,0 +1,34 @@
+package dialogs
+
+// NewMessage creates a dialog showing a message with the given buttons, or a single OK button
+// if none are given. The result tells which button was pressed
+func NewMessage(title, text string, buttons ...string) *Dialog {
+	if len(buttons) == 0 {
+		buttons = []string{"OK"}
+	}
+	return newMessage(title, text, buttons, nil)
+}
+
+// NewConfirm creates a dialog asking the user to confirm something with Yes or No.
+// The result is OK if the user chose Yes
+func NewConfirm(title, text string) *Dialog {
+	return newMessage(title, text, []string{"Yes", "No"}, map[string]bool{"No": true})
+}
+
+// newMessage creates a message dialog. Buttons in cancel close the dialog as cancelled
+func newMessage(title, text string, buttons []string, cancel map[string]bool) *Dialog {
+	d := newDialog(title, text)
+	form := d.newButtonForm()
+	buttonsWidth := 0
+	for _, label := range buttons {
+		label := label
+		form.AddButton(label, func() {
+			d.finish(Result{OK: !cancel[label], Button: label, Index: -1})
+		})
+		buttonsWidth += textWidth(label) + 6 // tview pads button labels and separates buttons
+	}
+	d.content.AddItem(form, formHeight(0), 0, true)
+	width, lines := dialogSize(text, max(textWidth(title), buttonsWidth))
+	d.setSize(width, 2+lines+formHeight(0))
+	return d
+}
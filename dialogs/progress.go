@@ -0,0 +1,201 @@
+package dialogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// size of progress dialogs
+const (
+	progressWidth    = 50
+	progressLogLines = 6
+	maxLogLines      = 500
+)
+
+// Progress tells how far the task of a progress dialog went
+type Progress struct {
+	Done  int64  // units of work done. Ignored, like Total, if both are zero
+	Total int64  // units of work in total, or 0 if unknown
+	Log   string // line to add to the log, if not empty
+}
+
+// Task is the work run by a progress dialog in its own goroutine. It must return
+// once the given context is cancelled, and reports its progress with the given function,
+// which can be called from any goroutine.
+type Task func(ctx context.Context, report func(progress Progress)) error
+
+// ProgressDialog is a dialog that runs a task, showing its progress with a bar,
+// the estimated time left and the lines it logs. The Cancel button cancels the context
+// of the task, and the dialog closes itself once the task returns. The result is OK
+// if the task returned no error, otherwise the error is in the Err field of the result.
+// Progress is shown through the QueueUpdate function of the window manager, so it needs
+// an update function set with SetUpdateFunc, usually one calling tview.Application.QueueUpdateDraw
+type ProgressDialog struct {
+	*Dialog
+	ctx    context.Context    // context of the task
+	stop   context.CancelFunc // cancels the context of the task
+	task   Task               // work to run
+	start  time.Time          // when the task started
+	bar    *progressBar       // progress bar
+	status *tview.TextView    // percentage and time left
+	log    *tview.TextView    // lines logged by the task
+	button *tview.Button      // cancel button
+
+	mutex     sync.Mutex // protects the fields below, which the task writes
+	latest    Progress   // last progress reported
+	lines     []string   // lines logged since the last refresh
+	scheduled bool       // whether a refresh is queued
+}
+
+// progressBar is a primitive that draws a horizontal bar filled in proportion to a fraction
+type progressBar struct {
+	*tview.Box
+	fraction float64 // filled fraction, between 0 and 1
+}
+
+// Draw draws this primitive onto the screen.
+// implements tview.Primitive.Draw
+func (pb *progressBar) Draw(screen tcell.Screen) {
+	pb.Box.DrawForSubclass(screen, pb)
+	x, y, width, height := pb.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	filled := int(pb.fraction * float64(width))
+	style := tcell.StyleDefault.Foreground(tview.Styles.PrimaryTextColor).Background(pb.GetBackgroundColor())
+	for i := 0; i < width; i++ {
+		ch := '░'
+		if i < filled {
+			ch = '█'
+		}
+		screen.SetContent(x+i, y, ch, nil, style)
+	}
+}
+
+// NewProgress creates a dialog that runs the given task once opened, with a context
+// derived from the given one
+func NewProgress(ctx context.Context, title, text string, task Task) *ProgressDialog {
+	p := &ProgressDialog{
+		Dialog: newDialog(title, text),
+		task:   task,
+		bar:    &progressBar{Box: tview.NewBox()},
+		status: tview.NewTextView().SetTextAlign(tview.AlignCenter),
+		log:    tview.NewTextView().SetMaxLines(maxLogLines),
+	}
+	p.ctx, p.stop = context.WithCancel(ctx)
+	p.button = tview.NewButton("Cancel").SetSelectedFunc(p.Cancel)
+	p.button.SetExitFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			p.Cancel()
+		}
+	})
+
+	buttons := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(p.button, 12, 0, true).
+		AddItem(nil, 0, 1, false)
+	controls := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(p.bar, 1, 0, false).
+		AddItem(p.status, 1, 0, false).
+		AddItem(p.log, progressLogLines, 0, false).
+		AddItem(buttons, 1, 0, true)
+	controls.SetBorderPadding(0, 0, 1, 1)
+	p.content.AddItem(controls, 3+progressLogLines, 0, true)
+
+	width, lines := dialogSize(text, max(textWidth(title), progressWidth-6))
+	p.setSize(width, 2+lines+3+progressLogLines)
+	return p
+}
+
+// Open opens the dialog like Dialog.Open, and starts the task. An error is returned,
+// and the dialog is not opened, if the window manager has no update function
+func (p *ProgressDialog) Open(wm *winman.Manager, parent winman.Window, setFocus func(p tview.Primitive)) (<-chan Result, error) {
+	if wm.GetUpdateFunc() == nil {
+		return nil, errors.New("dialogs: the window manager needs an update function to show progress")
+	}
+	p.start = time.Now()
+	result := p.Dialog.Open(wm, parent, setFocus)
+	go p.run()
+	return result, nil
+}
+
+// Context returns the context of the task, which is cancelled by the Cancel button
+func (p *ProgressDialog) Context() context.Context {
+	return p.ctx
+}
+
+// Cancel cancels the context of the task. The dialog closes once the task returns
+func (p *ProgressDialog) Cancel() {
+	p.stop()
+	p.button.SetLabel("Stopping…")
+}
+
+// run runs the task and closes the dialog once it returns
+func (p *ProgressDialog) run() {
+	err := p.task(p.ctx, p.report)
+	p.stop()
+	p.manager.QueueUpdate(func() {
+		p.refresh()
+		p.finish(Result{OK: err == nil, Index: -1, Err: err})
+	})
+}
+
+// report records the progress of the task and queues a refresh of the dialog.
+// Reports arriving before the refresh runs are shown together
+func (p *ProgressDialog) report(progress Progress) {
+	p.mutex.Lock()
+	if progress.Done != 0 || progress.Total != 0 {
+		p.latest.Done, p.latest.Total = progress.Done, progress.Total
+	}
+	if progress.Log != "" {
+		p.lines = append(p.lines, progress.Log)
+	}
+	scheduled := p.scheduled
+	p.scheduled = true
+	p.mutex.Unlock()
+	if !scheduled {
+		p.manager.QueueUpdate(p.refresh)
+	}
+}
+
+// refresh shows the progress reported so far. It runs in the event loop of the application
+func (p *ProgressDialog) refresh() {
+	p.mutex.Lock()
+	latest, lines := p.latest, p.lines
+	p.lines = nil
+	p.scheduled = false
+	p.mutex.Unlock()
+
+	for _, line := range lines {
+		fmt.Fprintln(p.log, tview.Escape(strings.TrimRight(line, "\n")))
+	}
+	if len(lines) > 0 {
+		p.log.ScrollToEnd()
+	}
+	if latest.Total <= 0 {
+		if latest.Done > 0 {
+			p.status.SetText(fmt.Sprintf("%d done", latest.Done))
+		}
+		return
+	}
+	fraction := float64(latest.Done) / float64(latest.Total)
+	if fraction > 1 {
+		fraction = 1
+	}
+	p.bar.fraction = fraction
+	status := fmt.Sprintf("%d%%", int(fraction*100))
+	if latest.Done > 0 && latest.Done < latest.Total {
+		elapsed := time.Since(p.start)
+		left := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+		status += fmt.Sprintf(", %s left", left.Round(time.Second))
+	}
+	p.status.SetText(status)
+}
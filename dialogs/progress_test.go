@@ -0,0 +1,105 @@
+package dialogs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/epiclabs-io/winman/dialogs"
+	"github.com/gdamore/tcell/v2"
+)
+
+// queueUpdates makes the window manager of the desktop queue its updates on the returned
+// channel, so tests run them as the event loop of an application would
+func (d *desktop) queueUpdates() <-chan func() {
+	updates := make(chan func(), 10)
+	d.wm.SetUpdateFunc(func(f func()) {
+		updates <- f
+	})
+	return updates
+}
+
+func TestProgress(t *testing.T) {
+	d := newDesktop()
+	step := make(chan bool)
+	reported := make(chan bool)
+	failure := errors.New("disk full")
+	progress := dialogs.NewProgress(context.Background(), "Copy", "Copying files", func(ctx context.Context, report func(dialogs.Progress)) error {
+		for i := int64(1); <-step; i++ {
+			report(dialogs.Progress{Done: i, Total: 4, Log: "copied file"})
+			reported <- true
+		}
+		return failure
+	})
+
+	// progress needs an update function
+	if _, err := progress.Open(d.wm, nil, d.setFocus); err == nil || d.wm.WindowCount() != 1 {
+		t.Fatal("Expected the dialog not to open without an update function")
+	}
+	updates := d.queueUpdates()
+	results, err := progress.Open(d.wm, nil, d.setFocus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.wm.Draw(d.screen)
+	if !progress.IsModal() || !progress.HasFocus() || !d.shows("Cancel") {
+		t.Fatal("Expected the dialog to be open, modal and focused")
+	}
+
+	// reports are shown together by a single update
+	step <- true
+	<-reported
+	step <- true
+	<-reported
+	d.wm.Draw(d.screen)
+	if d.shows("50%") {
+		t.Fatal("Expected progress not to be shown before the update runs")
+	}
+	(<-updates)()
+	d.wm.Draw(d.screen)
+	if !d.shows("50%") || !d.shows("copied file") || !d.shows("██") {
+		t.Fatal("Expected the progress bar, percentage and log to be shown")
+	}
+	if len(updates) != 0 {
+		t.Fatal("Expected a single update for both reports")
+	}
+
+	// the dialog closes itself once the task returns, reporting its error
+	step <- false
+	(<-updates)()
+	r := result(t, results)
+	if r.OK || r.Err != failure || d.wm.WindowCount() != 1 || !d.window.HasFocus() {
+		t.Fatalf("Expected the dialog to close reporting the task error, got %+v", r)
+	}
+	if progress.Context().Err() == nil {
+		t.Fatal("Expected the task context to be released")
+	}
+}
+
+func TestProgressCancel(t *testing.T) {
+	d := newDesktop()
+	updates := d.queueUpdates()
+	started := make(chan bool)
+	progress := dialogs.NewProgress(context.Background(), "Wait", "", func(ctx context.Context, report func(dialogs.Progress)) error {
+		started <- true
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	results, err := progress.Open(d.wm, d.window, d.setFocus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// Esc cancels the context, but the dialog stays open until the task returns
+	d.keys(tcell.KeyEscape)
+	<-progress.Context().Done()
+	if d.wm.WindowCount() != 2 || !d.shows("Stopping") {
+		t.Fatal("Expected the dialog to stay open while the task stops")
+	}
+	(<-updates)()
+	r := result(t, results)
+	if r.OK || r.Err != context.Canceled || !d.window.HasFocus() {
+		t.Fatalf("Expected the dialog to be cancelled, got %+v", r)
+	}
+}
@@ -3,7 +3,7 @@
 Package winman implements a basic yet powerful window manager that can be used
 with tview (github.com/rivo/tview).
 
-It supports floating windows that can be dragged, resized and maximized.
+It supports floating windows that can be dragged, resized, maximized and minimized.
 Windows can have buttons on the title bar, for example to close them,
 help commands or maximize / minimize.
 
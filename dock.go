@@ -0,0 +1,143 @@
+package winman
+
+import (
+	"github.com/rivo/tview"
+)
+
+// dockedPrimitive is a primitive drawn by the window manager on a strip
+// reserved on one of its edges
+type dockedPrimitive struct {
+	primitive tview.Primitive
+	edge      WindowEdge
+	size      int
+}
+
+// SetReserved reserves a strip of the given size on the given edge of the window manager.
+// Only EdgeTop, EdgeRight, EdgeBottom and EdgeLeft are valid.
+// Windows are never placed on reserved strips: maximizing, centering and keeping
+// windows within bounds only consider the remaining work area.
+// Reserved strips are counted from the outer edge, before any docked primitive
+func (wm *Manager) SetReserved(edge WindowEdge, size int) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	if size < 0 {
+		size = 0
+	}
+	switch edge {
+	case EdgeTop, EdgeRight, EdgeBottom, EdgeLeft:
+		if wm.reserved == nil {
+			wm.reserved = make(map[WindowEdge]int)
+		}
+		wm.reserved[edge] = size
+	}
+	return wm
+}
+
+// GetReserved returns the size of the strip reserved on the given edge,
+// including the space taken by docked primitives
+func (wm *Manager) GetReserved(edge WindowEdge) int {
+	wm.lock()
+	defer wm.unlock()
+	size := wm.reserved[edge]
+	for _, d := range wm.docked {
+		if d.edge == edge {
+			size += d.size
+		}
+	}
+	return size
+}
+
+// Dock reserves a strip of the given size on the given edge of the window manager
+// and has the window manager draw the given primitive on it, for example a Taskbar.
+// Primitives docked on the same edge are stacked from the outside in, in the order they were docked
+func (wm *Manager) Dock(primitive tview.Primitive, edge WindowEdge, size int) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	switch edge {
+	case EdgeTop, EdgeRight, EdgeBottom, EdgeLeft:
+		wm.undock(primitive)
+		wm.docked = append(wm.docked, dockedPrimitive{
+			primitive: primitive,
+			edge:      edge,
+			size:      size,
+		})
+	}
+	return wm
+}
+
+// Undock removes the given docked primitive, releasing the space it reserved
+func (wm *Manager) Undock(primitive tview.Primitive) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.undock(primitive)
+	return wm
+}
+
+func (wm *Manager) undock(primitive tview.Primitive) {
+	for i, d := range wm.docked {
+		if d.primitive == primitive {
+			wm.docked = append(wm.docked[:i], wm.docked[i+1:]...)
+			return
+		}
+	}
+}
+
+// layoutDocks positions docked primitives within the window manager and returns
+// the remaining work area where windows can be placed
+func (wm *Manager) layoutDocks() Rect {
+	area := NewRect(wm.GetInnerRect())
+
+	// take the space explicitly reserved first
+	area.X += wm.reserved[EdgeLeft]
+	area.W -= wm.reserved[EdgeLeft] + wm.reserved[EdgeRight]
+	area.Y += wm.reserved[EdgeTop]
+	area.H -= wm.reserved[EdgeTop] + wm.reserved[EdgeBottom]
+
+	// Top and bottom docks span the whole width available,
+	// left and right docks take the height left by the former
+	for _, horizontal := range []bool{true, false} {
+		for _, d := range wm.docked {
+			switch size := d.size; d.edge {
+			case EdgeTop:
+				if horizontal {
+					d.primitive.SetRect(area.X, area.Y, area.W, size)
+					area.Y += size
+					area.H -= size
+				}
+			case EdgeBottom:
+				if horizontal {
+					d.primitive.SetRect(area.X, area.Y+area.H-size, area.W, size)
+					area.H -= size
+				}
+			case EdgeLeft:
+				if !horizontal {
+					d.primitive.SetRect(area.X, area.Y, size, area.H)
+					area.X += size
+					area.W -= size
+				}
+			case EdgeRight:
+				if !horizontal {
+					d.primitive.SetRect(area.X+area.W-size, area.Y, size, area.H)
+					area.W -= size
+				}
+			}
+		}
+	}
+
+	if area.W < 0 {
+		area.W = 0
+	}
+	if area.H < 0 {
+		area.H = 0
+	}
+	return area
+}
+
+// WorkArea returns the coordinates of the area where windows are placed,
+// that is, the inner rect of the window manager minus any reserved strips
+func (wm *Manager) WorkArea() (int, int, int, int) {
+	wm.lock()
+	defer wm.unlock()
+	area := wm.layoutDocks()
+	return area.Rect()
+}
@@ -0,0 +1,123 @@
+package winman_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestDock(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	bottom := NewBoringPrimitive('_')
+	left := NewBoringPrimitive('|')
+
+	wm.SetReserved(winman.EdgeTop, 1).
+		Dock(bottom, winman.EdgeBottom, 1).
+		Dock(left, winman.EdgeLeft, 2)
+
+	workArea := winman.NewRect(wm.WorkArea())
+	expectedArea := winman.NewRect(2, 1, 18, 18)
+	if workArea != expectedArea {
+		t.Fatalf("Expected work area to be %s, got %s", expectedArea, workArea)
+	}
+
+	for _, edge := range []winman.WindowEdge{winman.EdgeTop, winman.EdgeBottom, winman.EdgeLeft} {
+		if reserved := wm.GetReserved(edge); reserved == 0 {
+			t.Fatalf("Expected edge %d to have space reserved", edge)
+		}
+	}
+	if reserved := wm.GetReserved(winman.EdgeRight); reserved != 0 {
+		t.Fatalf("Expected right edge to not have space reserved, got %d", reserved)
+	}
+
+	overflowing := wm.NewWindow().Show()
+	overflowing.SetRect(0, 0, 5, 5)
+	maximized := wm.NewWindow().Show().Maximize()
+	centered := wm.NewWindow().Show()
+	centered.SetRect(0, 0, 4, 4)
+	wm.Center(centered)
+
+	wm.Draw(screen)
+	sm.Sync()
+
+	if rect := winman.NewRect(overflowing.GetRect()); rect != winman.NewRect(2, 1, 5, 5) {
+		t.Fatalf("Expected window to be kept out of reserved strips, got %s", rect)
+	}
+	if rect := winman.NewRect(maximized.GetRect()); rect != expectedArea {
+		t.Fatalf("Expected maximized window to take the work area %s, got %s", expectedArea, rect)
+	}
+	if rect := winman.NewRect(centered.GetRect()); rect != winman.NewRect(9, 8, 4, 4) {
+		t.Fatalf("Expected window to be centered within the work area, got %s", rect)
+	}
+
+	// docked primitives are drawn on their strips
+	if rect := winman.NewRect(bottom.GetRect()); rect != winman.NewRect(0, 19, 20, 1) {
+		t.Fatalf("Expected bottom docked primitive to span the bottom edge, got %s", rect)
+	}
+	if rect := winman.NewRect(left.GetRect()); rect != winman.NewRect(0, 1, 2, 18) {
+		t.Fatalf("Expected left docked primitive to take the height left, got %s", rect)
+	}
+	if line := sm.Line(0, 19, 20); line != "____________________" {
+		t.Fatalf("Expected bottom docked primitive to be drawn, got %q", line)
+	}
+	if line := sm.Line(0, 10, 2); line != "||" {
+		t.Fatalf("Expected left docked primitive to be drawn, got %q", line)
+	}
+
+	// docked primitives receive the mouse events on their strips
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	wm.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(10, 19, tcell.Button1, tcell.ModNone), setFocus)
+	if bottom.clickCount != 1 {
+		t.Fatalf("Expected bottom docked primitive to receive a click, got %d", bottom.clickCount)
+	}
+
+	// undocking releases the space
+	wm.Undock(bottom).Undock(left)
+	workArea = winman.NewRect(wm.WorkArea())
+	expectedArea = winman.NewRect(0, 1, 20, 19)
+	if workArea != expectedArea {
+		t.Fatalf("Expected work area to be %s after undocking, got %s", expectedArea, workArea)
+	}
+}
+
+func TestDockTaskbar(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 10)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 10)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	wm.Dock(winman.NewTaskbar(wm), winman.EdgeBottom, 1)
+	wm.NewWindow().Show().SetTitle("Edit")
+
+	// the taskbar queries the window manager while it is drawn
+	drawn := make(chan bool)
+	go func() {
+		wm.Draw(screen)
+		close(drawn)
+	}()
+	select {
+	case <-drawn:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the window manager to draw a docked taskbar without blocking")
+	}
+	sm.Sync()
+
+	if line := sm.Line(0, 9, 20); !strings.Contains(line, "Edit") {
+		t.Fatalf("Expected the taskbar to list the window, got %q", line)
+	}
+}
@@ -0,0 +1,100 @@
+package winman
+
+// WindowEventType enumerates the different window lifecycle events
+type WindowEventType int16
+
+// Different window lifecycle events
+const (
+	WindowShow     WindowEventType = iota // the window became visible
+	WindowHide                            // the window was hidden
+	WindowFocus                           // the window got focus
+	WindowBlur                            // the window lost focus
+	WindowMove                            // the window changed position
+	WindowResize                          // the window changed size
+	WindowMaximize                        // the window was maximized
+	WindowMinimize                        // the window was minimized
+	WindowRestore                         // the window was restored from maximized or minimized
+	WindowZChange                         // the window z index changed
+	WindowClose                           // the window is about to close. Can be cancelled
+)
+
+// WindowEvent describes a change in a window
+type WindowEvent struct {
+	Type      WindowEventType // what happened
+	Window    Window          // window the event refers to, as added to the window manager
+	OldRect   Rect            // coordinates before a WindowMove or WindowResize event
+	cancelled bool
+}
+
+// Cancel cancels a WindowClose event, so the window is kept open.
+// It has no effect on other events
+func (e *WindowEvent) Cancel() {
+	e.cancelled = true
+}
+
+// IsCancelled returns true if the event was cancelled
+func (e *WindowEvent) IsCancelled() bool {
+	return e.cancelled
+}
+
+// SetEventHandler sets a function that is called for every event of every window
+// in this window manager, after the handler of the window itself, if any.
+// Events raised while the window manager is drawing or handling input are
+// delivered once it is done, so the handler can safely call the window manager.
+func (wm *Manager) SetEventHandler(handler func(event *WindowEvent)) *Manager {
+	wm.eventMutex.Lock()
+	defer wm.eventMutex.Unlock()
+	wm.eventHandler = handler
+	return wm
+}
+
+// lock locks the window manager, holding back events until unlock is called
+func (wm *Manager) lock() {
+	wm.Lock()
+	wm.eventMutex.Lock()
+	wm.holdEvents = true
+	wm.eventMutex.Unlock()
+}
+
+// unlock unlocks the window manager and delivers events raised meanwhile
+func (wm *Manager) unlock() {
+	wm.eventMutex.Lock()
+	wm.holdEvents = false
+	events := wm.pendingEvents
+	wm.pendingEvents = nil
+	wm.eventMutex.Unlock()
+	wm.Unlock()
+	for _, event := range events {
+		wm.deliver(event)
+	}
+}
+
+// dispatch delivers the given event, or queues it if the window manager is locked
+func (wm *Manager) dispatch(event *WindowEvent) {
+	wm.eventMutex.Lock()
+	if wm.holdEvents {
+		wm.pendingEvents = append(wm.pendingEvents, event)
+		wm.eventMutex.Unlock()
+		return
+	}
+	wm.eventMutex.Unlock()
+	wm.deliver(event)
+}
+
+// deliver calls the event handlers of the window and the window manager
+func (wm *Manager) deliver(event *WindowEvent) {
+	if base := getBase(event.Window); base != nil && base.eventHandler != nil {
+		base.eventHandler(event)
+	}
+	wm.eventMutex.Lock()
+	handler := wm.eventHandler
+	wm.eventMutex.Unlock()
+	if handler != nil {
+		handler(event)
+	}
+}
+
+// emit raises an event of the given type for the given window
+func (wm *Manager) emit(window Window, eventType WindowEventType) {
+	wm.dispatch(&WindowEvent{Type: eventType, Window: window})
+}
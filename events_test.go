@@ -0,0 +1,134 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// EditorWindow is a window type that embeds WindowBase
+type EditorWindow struct {
+	*winman.WindowBase
+	dirty bool
+}
+
+type EventRecorder struct {
+	events []winman.WindowEvent
+}
+
+func (er *EventRecorder) Record(event *winman.WindowEvent) {
+	er.events = append(er.events, *event)
+}
+
+func (er *EventRecorder) Check(t *testing.T, expected ...winman.WindowEventType) {
+	t.Helper()
+	if len(er.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(er.events), er.events)
+	}
+	for i, eventType := range expected {
+		if er.events[i].Type != eventType {
+			t.Fatalf("Expected event #%d to be of type %d, got %d", i, eventType, er.events[i].Type)
+		}
+	}
+	er.events = nil
+}
+
+func TestWindowEvents(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	windowEvents := &EventRecorder{}
+	managerEvents := &EventRecorder{}
+
+	editor := &EditorWindow{WindowBase: winman.NewWindow()}
+	editor.SetEventHandler(func(event *winman.WindowEvent) {
+		if event.Window != editor {
+			t.Fatalf("Expected event to refer to the editor window, got %v", event.Window)
+		}
+		windowEvents.Record(event)
+		if event.Type == winman.WindowClose && editor.dirty {
+			event.Cancel()
+		}
+	})
+
+	// windows outside a window manager also raise events
+	standalone := winman.NewWindow().SetEventHandler(windowEvents.Record)
+	standalone.SetRect(1, 1, 5, 5)
+	standalone.Show()
+	windowEvents.Check(t, winman.WindowMove, winman.WindowResize, winman.WindowShow)
+
+	other := wm.NewWindow()
+	other.SetRect(0, 0, 5, 5)
+	wm.AddWindow(editor)
+	editor.SetRect(1, 1, 5, 5)
+	windowEvents.Check(t, winman.WindowMove, winman.WindowResize)
+	wm.SetEventHandler(func(event *winman.WindowEvent) {
+		managerEvents.Record(event)
+		wm.GetZ(event.Window) // handlers can call the window manager
+	})
+
+	editor.Show()
+	editor.Show() // showing an already visible window does nothing
+	windowEvents.Check(t, winman.WindowShow)
+	managerEvents.Check(t, winman.WindowShow)
+
+	// geometry changes made by the window manager are notified
+	editor.SetRect(-1, 1, 5, 5)
+	windowEvents.Check(t, winman.WindowMove)
+	wm.Draw(screen)
+	if oldRect := windowEvents.events[0].OldRect; oldRect != winman.NewRect(-1, 1, 5, 5) {
+		t.Fatalf("Expected the move event to carry the former coordinates, got %s", oldRect)
+	}
+	windowEvents.Check(t, winman.WindowMove)
+	managerEvents.events = nil
+
+	editor.Maximize()
+	wm.Draw(screen)
+	windowEvents.Check(t, winman.WindowMaximize, winman.WindowMove, winman.WindowResize)
+	editor.Restore()
+	windowEvents.Check(t, winman.WindowMove, winman.WindowResize, winman.WindowRestore)
+	editor.Minimize()
+	editor.Restore()
+	windowEvents.Check(t, winman.WindowMinimize, winman.WindowRestore)
+
+	// focus and z index changes
+	other.Show()
+	setFocus(other)
+	wm.Draw(screen)
+	managerEvents.events = nil
+	windowEvents.events = nil
+	setFocus(editor)
+	wm.Draw(screen)
+	windowEvents.Check(t, winman.WindowZChange, winman.WindowFocus)
+	managerEvents.Check(t, winman.WindowZChange, winman.WindowBlur, winman.WindowFocus)
+
+	wm.SetZ(editor, winman.WindowZBottom)
+	windowEvents.Check(t, winman.WindowZChange)
+	managerEvents.Check(t, winman.WindowZChange)
+
+	// closing can be cancelled
+	editor.dirty = true
+	if editor.Close() {
+		t.Fatal("Expected Close to be cancelled")
+	}
+	windowEvents.Check(t, winman.WindowClose)
+	if !editor.IsVisible() || wm.GetZ(editor) == -1 {
+		t.Fatal("Expected cancelled close to keep the window open")
+	}
+
+	editor.dirty = false
+	if !editor.Close() {
+		t.Fatal("Expected window to close")
+	}
+	windowEvents.Check(t, winman.WindowClose, winman.WindowHide)
+	if editor.IsVisible() || wm.GetZ(editor) != -1 {
+		t.Fatal("Expected closed window to be hidden and removed from the window manager")
+	}
+}
@@ -0,0 +1,22 @@
+package winman
+
+import "github.com/gdamore/tcell/v2"
+
+// Hotkey represents a key combination that triggers a window manager command.
+// The zero value is never matched, disabling the command
+type Hotkey struct {
+	Key       tcell.Key     // key to be pressed. Use tcell.KeyRune for characters
+	Rune      rune          // character to be pressed when Key is tcell.KeyRune
+	Modifiers tcell.ModMask // modifier keys that must be held down
+}
+
+// Matches returns true if the given key event corresponds to this hotkey
+func (h Hotkey) Matches(event *tcell.EventKey) bool {
+	if h == (Hotkey{}) {
+		return false
+	}
+	if event.Key() != h.Key || event.Modifiers() != h.Modifiers {
+		return false
+	}
+	return h.Key != tcell.KeyRune || event.Rune() == h.Rune
+}
@@ -0,0 +1,68 @@
+package winman
+
+// Layer groups windows in the z order. Windows in a higher layer are always
+// above windows in a lower layer, and z indexes are relative to the layer of a window
+type Layer int16
+
+// Different layers, from the bottom up
+const (
+	LayerDesktop      Layer = iota - 1 // windows that stay below all others, like desktop widgets
+	LayerNormal                        // regular windows. This is the default layer
+	LayerAlwaysOnTop                   // windows that stay above regular windows, like monitors
+	LayerPopup                         // popups and menus
+	LayerNotification                  // notifications, above everything else
+)
+
+// getLayer returns the layer of the given window. Windows are never in a lower layer than their parent
+func getLayer(window Window) Layer {
+	layer := LayerNormal
+	if l, ok := window.(interface{ GetLayer() Layer }); ok {
+		layer = l.GetLayer()
+	}
+	for _, parent := range ancestors(window) {
+		if l, ok := parent.(interface{ GetLayer() Layer }); ok && l.GetLayer() > layer {
+			layer = l.GetLayer()
+		}
+	}
+	return layer
+}
+
+// sortLayers moves windows so that they are sorted by layer,
+// keeping the order of windows within the same layer
+func (wm *Manager) sortLayers() {
+	for i := 1; i < len(wm.windows); i++ {
+		window := wm.windows[i]
+		layer := getLayer(window.(Window))
+		j := i
+		for ; j > 0 && getLayer(wm.windows[j-1].(Window)) > layer; j-- {
+			wm.windows[j] = wm.windows[j-1]
+		}
+		wm.windows[j] = window
+	}
+}
+
+// layerRange returns the index of the bottommost window of the given layer
+// and the index right after its topmost window. Windows must be sorted by layer
+func (wm *Manager) layerRange(layer Layer) (start, end int) {
+	for start < len(wm.windows) && getLayer(wm.windows[start].(Window)) < layer {
+		start++
+	}
+	end = start
+	for end < len(wm.windows) && getLayer(wm.windows[end].(Window)) == layer {
+		end++
+	}
+	return start, end
+}
+
+// LayerWindows returns the windows in the given layer, from the bottom to the top
+func (wm *Manager) LayerWindows(layer Layer) []Window {
+	wm.lock()
+	defer wm.unlock()
+	wm.sortLayers()
+	start, end := wm.layerRange(layer)
+	var windows []Window
+	for _, wndItem := range wm.windows[start:end] {
+		windows = append(windows, wndItem.(Window))
+	}
+	return windows
+}
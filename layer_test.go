@@ -0,0 +1,74 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestLayers(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 80, 40)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 40)
+	screen.Init()
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	monitor := wm.NewWindow().Show().SetLayer(winman.LayerAlwaysOnTop)
+	wndA := wm.NewWindow().Show()
+	wndB := wm.NewWindow().Show()
+	background := wm.NewWindow().Show().SetLayer(winman.LayerDesktop)
+	wm.Draw(screen)
+
+	// windows are ordered by layer, then by z within their layer
+	expected := []winman.Window{background, wndA, wndB, monitor}
+	for i, wnd := range expected {
+		if wm.Window(i) != wnd {
+			t.Fatalf("Expected window #%d of the stack to be %v, got %v", i, wnd, wm.Window(i))
+		}
+	}
+	if wm.GetZ(wndA) != 0 || wm.GetZ(wndB) != 1 || wm.GetZ(monitor) != 0 || wm.GetZ(background) != 0 {
+		t.Fatal("Expected z indexes to be relative to the layer of each window")
+	}
+
+	// focus raising does not cross layers
+	setFocus(wndA)
+	wm.Draw(screen)
+	if wm.GetZ(wndA) != 1 || wm.Window(3) != monitor {
+		t.Fatal("Expected the focused window to be raised within its layer only")
+	}
+	setFocus(background)
+	wm.Draw(screen)
+	if wm.Window(0) != background {
+		t.Fatal("Expected desktop windows to stay at the bottom when focused")
+	}
+
+	// SetZ works within the layer of the window
+	wm.SetZ(wndA, winman.WindowZBottom)
+	if wm.GetZ(wndA) != 0 || wm.Window(1) != wndA {
+		t.Fatal("Expected SetZ to move the window to the bottom of its layer")
+	}
+	wm.SetZ(wndA, 5)
+	if wm.GetZ(wndA) != 1 || wm.Window(2) != wndA {
+		t.Fatal("Expected SetZ to move the window to the top of its layer")
+	}
+
+	// changing the layer of a window moves it to the new layer
+	wndB.SetLayer(winman.LayerNotification)
+	if wm.Window(3) != wndB {
+		t.Fatal("Expected the window to move to the notification layer")
+	}
+	if windows := wm.LayerWindows(winman.LayerNormal); len(windows) != 1 || windows[0] != wndA {
+		t.Fatalf("Expected only one window in the normal layer, got %v", windows)
+	}
+
+	// children are never below the layer of their parent
+	child := wm.NewWindow().Show().SetParent(monitor)
+	wm.Draw(screen)
+	if windows := wm.LayerWindows(winman.LayerAlwaysOnTop); len(windows) != 2 || windows[1] != child {
+		t.Fatalf("Expected the child to be in the layer of its parent, got %v", windows)
+	}
+}
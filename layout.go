@@ -0,0 +1,107 @@
+package winman
+
+import "math"
+
+// Layout arranges the tiled windows of a window manager.
+// Windows that are modal, maximized or floating are never tiled.
+type Layout interface {
+	// Arrange returns the coordinates of count windows tiled within the given area,
+	// in the order the windows were added to the window manager.
+	// A nil result leaves all windows floating
+	Arrange(area Rect, count int) []Rect
+}
+
+// FloatingLayout does not tile windows, letting the user place them anywhere.
+// This is the default layout
+type FloatingLayout struct{}
+
+// Arrange implements Layout
+func (FloatingLayout) Arrange(area Rect, count int) []Rect {
+	return nil
+}
+
+// DefaultMasterRatio is the fraction of the width given to the master window
+// in a MasterStackLayout that does not set a ratio
+const DefaultMasterRatio = 0.5
+
+// MasterStackLayout places the first window on the left (the master) and stacks
+// the rest on the right side, one above another
+type MasterStackLayout struct {
+	Ratio float64 // fraction of the width taken by the master window
+}
+
+// Arrange implements Layout
+func (l MasterStackLayout) Arrange(area Rect, count int) []Rect {
+	if count == 0 {
+		return []Rect{}
+	}
+	if count == 1 {
+		return []Rect{area}
+	}
+	ratio := l.Ratio
+	if ratio <= 0 || ratio >= 1 {
+		ratio = DefaultMasterRatio
+	}
+	masterWidth := int(float64(area.W) * ratio)
+	rects := []Rect{NewRect(area.X, area.Y, masterWidth, area.H)}
+	y := area.Y
+	for _, h := range split(area.H, count-1) {
+		rects = append(rects, NewRect(area.X+masterWidth, y, area.W-masterWidth, h))
+		y += h
+	}
+	return rects
+}
+
+// GridLayout arranges windows in a grid with as many columns as rows, or one more column
+type GridLayout struct{}
+
+// Arrange implements Layout
+func (GridLayout) Arrange(area Rect, count int) []Rect {
+	rects := []Rect{}
+	if count == 0 {
+		return rects
+	}
+	cols := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := (count + cols - 1) / cols
+	y := area.Y
+	for row, h := range split(area.H, rows) {
+		// the last row may have less windows, which share the entire width
+		rowCount := cols
+		if row == rows-1 {
+			rowCount = count - cols*(rows-1)
+		}
+		x := area.X
+		for _, w := range split(area.W, rowCount) {
+			rects = append(rects, NewRect(x, y, w, h))
+			x += w
+		}
+		y += h
+	}
+	return rects
+}
+
+// MonocleLayout makes all windows take the entire area.
+// Only the window on top, usually the focused one, can be seen
+type MonocleLayout struct{}
+
+// Arrange implements Layout
+func (MonocleLayout) Arrange(area Rect, count int) []Rect {
+	rects := make([]Rect, count)
+	for i := range rects {
+		rects[i] = area
+	}
+	return rects
+}
+
+// split divides the given size in the given number of parts,
+// giving the remainder to the first parts
+func split(size, parts int) []int {
+	sizes := make([]int, parts)
+	for i := range sizes {
+		sizes[i] = size / parts
+		if i < size%parts {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,101 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+)
+
+type LayoutTest struct {
+	layout   winman.Layout
+	count    int
+	expected []Rect
+}
+
+var layoutTests = []LayoutTest{
+	{winman.FloatingLayout{}, 3, nil},
+	{winman.MasterStackLayout{}, 0, []Rect{}},
+	{winman.MasterStackLayout{}, 1, []Rect{{0, 0, 20, 10}}},
+	{winman.MasterStackLayout{}, 4, []Rect{{0, 0, 10, 10}, {10, 0, 10, 4}, {10, 4, 10, 3}, {10, 7, 10, 3}}},
+	{winman.MasterStackLayout{Ratio: 0.25}, 2, []Rect{{0, 0, 5, 10}, {5, 0, 15, 10}}},
+	{winman.GridLayout{}, 0, []Rect{}},
+	{winman.GridLayout{}, 4, []Rect{{0, 0, 10, 5}, {10, 0, 10, 5}, {0, 5, 10, 5}, {10, 5, 10, 5}}},
+	{winman.GridLayout{}, 3, []Rect{{0, 0, 10, 5}, {10, 0, 10, 5}, {0, 5, 20, 5}}},
+	{winman.GridLayout{}, 5, []Rect{{0, 0, 7, 5}, {7, 0, 7, 5}, {14, 0, 6, 5}, {0, 5, 10, 5}, {10, 5, 10, 5}}},
+	{winman.MonocleLayout{}, 2, []Rect{{0, 0, 20, 10}, {0, 0, 20, 10}}},
+}
+
+func TestLayoutArrange(t *testing.T) {
+	area := winman.NewRect(0, 0, 20, 10)
+	for i, lt := range layoutTests {
+		rects := lt.layout.Arrange(area, lt.count)
+		if (rects == nil) != (lt.expected == nil) || len(rects) != len(lt.expected) {
+			t.Fatalf("layout test #%d: Expected %v, got %v", i, lt.expected, rects)
+		}
+		for j := range rects {
+			if rects[j] != lt.expected[j] {
+				t.Fatalf("layout test #%d: Expected rect #%d to be %s, got %s", i, j, lt.expected[j], rects[j])
+			}
+		}
+	}
+}
+
+func TestManagerLayout(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 10)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 10)
+	screen.Init()
+
+	if _, ok := wm.GetLayout().(winman.FloatingLayout); !ok {
+		t.Fatalf("Expected the default layout to be floating, got %T", wm.GetLayout())
+	}
+
+	master := wm.NewWindow().Show()
+	master.SetRect(1, 1, 5, 5)
+	modal := wm.NewWindow().Show().SetModal(true)
+	modal.SetRect(2, 2, 5, 5)
+	floating := wm.NewWindow().Show().SetFloating(true)
+	floating.SetRect(3, 3, 5, 5)
+	hidden := wm.NewWindow()
+	hidden.SetRect(4, 4, 5, 5)
+	stacked := wm.NewWindow().Show()
+	stacked.SetRect(5, 5, 5, 5)
+
+	// floating layout leaves windows where they are
+	wm.Draw(screen)
+	if rect := winman.NewRect(master.GetRect()); rect != winman.NewRect(1, 1, 5, 5) {
+		t.Fatalf("Expected floating layout to not move windows, got %s", rect)
+	}
+
+	wm.SetLayout(winman.MasterStackLayout{})
+	stacked.Focus(delegate)
+	wm.Draw(screen)
+
+	expected := map[*winman.WindowBase]Rect{
+		master:   {0, 0, 10, 10},
+		stacked:  {10, 0, 10, 10},
+		modal:    {2, 2, 5, 5},
+		floating: {3, 3, 5, 5},
+		hidden:   {4, 4, 5, 5},
+	}
+	for wnd, rect := range expected {
+		if r := winman.NewRect(wnd.GetRect()); r != rect {
+			t.Fatalf("Expected window %s to be at %s, got %s", wnd.GetTitle(), rect, r)
+		}
+	}
+
+	// floating windows stay above tiled windows, even the focused one
+	if wm.GetZ(stacked) > wm.GetZ(floating) || wm.GetZ(stacked) > wm.GetZ(modal) {
+		t.Fatal("Expected floating and modal windows to stay above the focused tiled window")
+	}
+
+	// switching back to floating keeps windows where they were tiled
+	wm.SetLayout(nil)
+	master.SetRect(1, 1, 5, 5)
+	wm.Draw(screen)
+	if rect := winman.NewRect(master.GetRect()); rect != winman.NewRect(1, 1, 5, 5) {
+		t.Fatalf("Expected window to float again, got %s", rect)
+	}
+}
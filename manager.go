@@ -19,18 +19,22 @@ const (
 	EdgeLeft
 	EdgeBottomRight
 	EdgeBottomLeft
+	EdgeTopLeft
+	EdgeTopRight
 )
 
-// WindowZTop is used with SetZ to move a window to the top
+// WindowZTop is used with SetZ to move a window to the top of its layer
 const WindowZTop = -1
 
-// WindowZBottom is used with SetZ to move a window to the bottom
+// WindowZBottom is used with SetZ to move a window to the bottom of its layer
 const WindowZBottom = 0
 
-// MinWindowWidth sets the minimum width a window can have as part of a window manager
+// MinWindowWidth sets the minimum width a window can have as part of a window manager,
+// unless the window sets its own minimum width with SizeConstraints
 var MinWindowWidth = 3
 
-// MinWindowHeight sets the minimum height a window can have as part of a window manager
+// MinWindowHeight sets the minimum height a window can have as part of a window manager,
+// unless the window sets its own minimum height with SizeConstraints
 var MinWindowHeight = 3
 
 // inRect returns true if the given coordinates are within the window
@@ -38,6 +42,33 @@ func inRect(wnd Window, x, y int) bool {
 	return NewRect(wnd.GetRect()).Contains(x, y)
 }
 
+// onScreen returns true if the window is visible and not minimized,
+// and so are its parents
+func onScreen(wnd Window) bool {
+	for _, w := range append([]Window{wnd}, ancestors(wnd)...) {
+		if !w.IsVisible() || w.IsMinimized() {
+			return false
+		}
+	}
+	return true
+}
+
+// isFloating returns true if the window must not be tiled
+func isFloating(wnd Window) bool {
+	f, ok := wnd.(interface{ IsFloating() bool })
+	return wnd.IsModal() || wnd.IsMaximized() || getParent(wnd) != nil || ok && f.IsFloating()
+}
+
+// windowState holds what the window manager keeps track of for each window
+type windowState struct {
+	snap          SnapZone // zone of the work area the window is snapped to
+	snapRestore   Rect     // coordinates the window had before snapping
+	preferred     Rect     // coordinates last requested for the window
+	preferredArea Rect     // work area at the time the coordinates were requested
+	applied       Rect     // coordinates the window manager gave the window on the last Draw
+	sticky        bool     // whether the window is shown on every workspace
+}
+
 // Manager represents a Window Manager primitive
 type Manager struct {
 	*tview.Box
@@ -45,16 +76,73 @@ type Manager struct {
 	// The windows to be positioned.
 	windows Stack
 
+	// The same windows, in the order they were added
+	order Stack
+
+	// The windows that had focus, the most recent on top
+	history Stack
+
+	workspaces      []*workspace // workspaces, keeping the windows of the inactive ones
+	activeWorkspace int          // index of the workspace whose windows are in the stacks above
+
+	reserved map[WindowEdge]int // space reserved on each edge
+	docked   []dockedPrimitive  // primitives drawn on reserved edges
+	layout   Layout             // how windows are tiled
+	workArea Rect               // work area as of the last Draw
+
+	desktopWidth, desktopHeight int           // size of the desktop, if larger than the work area
+	viewportX, viewportY        int           // offset of the desktop shown in the work area
+	panModifiers                tcell.ModMask // modifiers that pan the viewport with the arrow keys
+
+	states map[Window]*windowState // additional state kept for each window
+
+	snapToEdges bool     // whether windows snap when dragged to the edges
+	snapPreview SnapZone // zone the dragged window will snap to when released
+
+	moveResizeHotkey  Hotkey   // key combination to move and resize windows with the keyboard
+	moveResizeWindow  Window   // window being moved and resized with the keyboard
+	moveResizeRestore Rect     // coordinates to restore if moving and resizing is cancelled
+	moveResizeSnap    SnapZone // snap zone to restore if moving and resizing is cancelled
+
+	switcherHotkey  Hotkey   // key combination to open the window switcher
+	switcherWindows []Window // windows listed while the switcher is open
+	switcherIndex   int      // selected window in the switcher
+
+	factories map[string]WindowFactory // window factories by type name, used when loading a layout
+
+	focused       Window                   // window that had focus as of the last Draw
+	eventHandler  func(event *WindowEvent) // function called on window events
+	holdEvents    bool                     // whether events must be queued until unlock
+	pendingEvents []*WindowEvent           // events raised while locked
+	eventMutex    sync.Mutex               // protects the event fields above
+
+	updateFunc     func(f func()) // function that runs updates in the event loop of the application
+	pendingUpdates []func()       // updates queued until the next Draw
+	updateMutex    sync.Mutex     // protects the update fields above
+
+	topResizeModifiers tcell.ModMask // modifiers that turn a title bar drag into a resize from the top edge
+
+	undoSteps [][]geometry // window moves and resizes that can be undone
+	dragStart geometry     // place of the dragged window when the drag started
+
+	placement      Placement // where new windows are placed
+	cascadeIndex   int       // position of the next cascaded window
+	mouseX, mouseY int       // last known mouse position
+
+	mouseCapture  tview.Primitive // primitive within a window that captured the mouse
+	modalBackdrop StyleFilter     // style filter used to draw what is blocked by a modal window
+
 	dragOffsetX, dragOffsetY int
 	draggedWindow            Window
-	draggedEdge              WindowEdge
+	draggedEdge              WindowEdge // edge being dragged to resize, or EdgeNone when moving the window
 	sync.Mutex
 }
 
 // NewWindowManager returns a ready to use window manager
 func NewWindowManager() *Manager {
 	wm := &Manager{
-		Box: tview.NewBox(),
+		Box:    tview.NewBox(),
+		layout: FloatingLayout{},
 	}
 	return wm
 }
@@ -68,23 +156,69 @@ func (wm *Manager) NewWindow() *WindowBase {
 
 // AddWindow adds the given window to the window manager
 func (wm *Manager) AddWindow(window Window) *Manager {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	wm.windows.Push(window)
+	wm.order.Push(window)
+	if base := getBase(window); base != nil {
+		base.manager = wm
+		base.self = window
+	}
+	wm.sortLayers()
 	return wm
 }
 
 // RemoveWindow removes the given window from this window manager
 func (wm *Manager) RemoveWindow(window Window) *Manager {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	wm.windows.Remove(window)
+	wm.order.Remove(window)
+	wm.history.Remove(window)
+	for _, ws := range wm.workspaces {
+		ws.windows.Remove(window)
+		ws.order.Remove(window)
+		ws.history.Remove(window)
+	}
+	wm.switcherWindows = nil
+	delete(wm.states, window)
+	wm.forgetUndo(window)
+	if wm.moveResizeWindow == window {
+		wm.moveResizeWindow = nil
+	}
+	if wm.focused == window {
+		wm.focused = nil
+	}
+	if base := getBase(window); base != nil && base.manager == wm {
+		base.manager = nil
+		base.self = nil
+	}
 	return wm
 }
 
-// Center centers the given window relative to the window manager
+// state returns the state kept for the given window
+func (wm *Manager) state(window Window) *windowState {
+	state, ok := wm.states[window]
+	if !ok {
+		if wm.states == nil {
+			wm.states = make(map[Window]*windowState)
+		}
+		state = &windowState{}
+		wm.states[window] = state
+	}
+	return state
+}
+
+// Center centers the given window on its parent, if it has a parent that is on screen,
+// or otherwise on the visible part of the window manager work area
 func (wm *Manager) Center(window Window) *Manager {
-	mx, my, mw, mh := wm.GetInnerRect()
+	wm.lock()
+	defer wm.unlock()
+	area := wm.visibleRect(wm.layoutDocks())
+	if parent := getParent(window); parent != nil && wm.windows.IndexOf(parent) != -1 && onScreen(parent) {
+		area = NewRect(parent.GetRect())
+	}
+	mx, my, mw, mh := area.Rect()
 	_, _, width, height := window.GetRect()
 	x := mx + (mw-width)/2
 	y := my + (mh-height)/2
@@ -92,42 +226,162 @@ func (wm *Manager) Center(window Window) *Manager {
 	return wm
 }
 
+// SetLayout changes how windows are tiled. Use FloatingLayout{} to stop tiling windows
+func (wm *Manager) SetLayout(layout Layout) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	if layout == nil {
+		layout = FloatingLayout{}
+	}
+	wm.layout = layout
+	return wm
+}
+
+// GetLayout returns the current layout
+func (wm *Manager) GetLayout() Layout {
+	wm.lock()
+	defer wm.unlock()
+	return wm.layout
+}
+
+// SetTopResizeModifiers sets the modifier keys that, held while pressing the mouse button on
+// the title bar of a window, resize the window from its top edge instead of moving it.
+// tcell.ModNone disables resizing from the top edge, which is the default.
+// Windows can always be resized from their top corners.
+func (wm *Manager) SetTopResizeModifiers(modifiers tcell.ModMask) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.topResizeModifiers = modifiers
+	return wm
+}
+
+// GetTopResizeModifiers returns the modifier keys that resize windows from their top edge
+func (wm *Manager) GetTopResizeModifiers() tcell.ModMask {
+	wm.lock()
+	defer wm.unlock()
+	return wm.topResizeModifiers
+}
+
 // WindowCount returns the number of windows managed by this window manager
 func (wm *Manager) WindowCount() int {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	return len(wm.windows)
 }
 
-// Window returns the window at the given z index
+// Window returns the window at the given z index, counting from the bottom
+// of the lowest layer up. With all windows in the same layer, this is the z index of GetZ
 func (wm *Manager) Window(z int) Window {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
+	wm.sortLayers()
 	wnd, _ := wm.windows.Item(z).(Window)
 	return wnd
 }
 
+// windowList returns a copy of the managed windows, from the bottom to the top of the z order
+func (wm *Manager) windowList() []Window {
+	windows := make([]Window, len(wm.windows))
+	for i, wndItem := range wm.windows {
+		windows[i] = wndItem.(Window)
+	}
+	return windows
+}
+
+func (wm *Manager) windowByID(id string) Window {
+	stacks := []Stack{wm.windows}
+	for i, ws := range wm.workspaces {
+		if i != wm.activeWorkspace {
+			stacks = append(stacks, ws.windows)
+		}
+	}
+	for _, stack := range stacks {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if window, ok := stack[i].(interface{ GetID() string }); ok && window.GetID() == id {
+				return window.(Window)
+			}
+		}
+	}
+	return nil
+}
+
+// WindowByID returns the window with the given id, or nil if there is none.
+// Windows of the active workspace are searched first.
+// If several windows share the same id, the topmost one is returned
+func (wm *Manager) WindowByID(id string) Window {
+	wm.lock()
+	defer wm.unlock()
+	return wm.windowByID(id)
+}
+
+// FindWindows returns the windows for which the given function returns true,
+// from the bottom to the top of the z order
+func (wm *Manager) FindWindows(predicate func(window Window) bool) []Window {
+	var found []Window
+	wm.ForEachWindow(func(window Window) bool {
+		if predicate(window) {
+			found = append(found, window)
+		}
+		return true
+	})
+	return found
+}
+
+// ForEachWindow calls the given function for every window, from the bottom to the
+// top of the z order, until it returns false. The function can safely call the window manager,
+// since it iterates over the windows managed at the time ForEachWindow was called
+func (wm *Manager) ForEachWindow(f func(window Window) bool) {
+	wm.lock()
+	windows := wm.windowList()
+	wm.unlock()
+	for _, window := range windows {
+		if !f(window) {
+			return
+		}
+	}
+}
+
 func (wm *Manager) getZ(window Window) int {
-	return wm.windows.IndexOf(window)
+	wm.sortLayers()
+	i := wm.windows.IndexOf(window)
+	if i == -1 {
+		return -1
+	}
+	start, _ := wm.layerRange(getLayer(window))
+	return i - start
 }
 
-// GetZ returns the z index of the given window
+// GetZ returns the z index of the given window within its layer
 // returns -1 if the given window is not part of this manager
 func (wm *Manager) GetZ(window Window) int {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	return wm.getZ(window)
 }
 
+// moveTo moves the given window to the given index of the whole stack of windows
+func (wm *Manager) moveTo(window Window, index int) {
+	oldIndex := wm.windows.IndexOf(window)
+	wm.windows.Move(window, index)
+	if oldIndex != wm.windows.IndexOf(window) {
+		wm.emit(window, WindowZChange)
+	}
+}
+
 func (wm *Manager) setZ(window Window, newZ int) {
-	wm.windows.Move(window, newZ)
+	wm.sortLayers()
+	start, end := wm.layerRange(getLayer(window))
+	if newZ < 0 || start+newZ >= end {
+		newZ = end - 1 - start
+	}
+	wm.moveTo(window, start+newZ)
 }
 
-// SetZ moves the given window to the given z index
+// SetZ moves the given window to the given z index within its layer
 // The special constants WindowZTop and WindowZBottom can be used
 func (wm *Manager) SetZ(window Window, newZ int) *Manager {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	wm.setZ(window, newZ)
 	return wm
 }
@@ -135,25 +389,31 @@ func (wm *Manager) SetZ(window Window, newZ int) *Manager {
 // Focus is called when this primitive receives focus
 // implements tview.Primitive.Focus
 func (wm *Manager) Focus(delegate func(p tview.Primitive)) {
-	wm.Lock()
+	wm.lock()
 
-	window, _ := wm.windows.Find(func(wi interface{}) bool {
-		return wi.(Window).IsVisible()
+	// give focus to the window that had it last, or else to the topmost one
+	window, _ := wm.history.Find(func(wi interface{}) bool {
+		return onScreen(wi.(Window))
 	}).(Window)
+	if window == nil {
+		window, _ = wm.windows.Find(func(wi interface{}) bool {
+			return onScreen(wi.(Window))
+		}).(Window)
+	}
 
 	if window != nil {
-		wm.Unlock()
+		wm.unlock()
 		window.Focus(delegate)
 		return
 	}
-	wm.Unlock()
+	wm.unlock()
 }
 
 // HasFocus returns whether or not this primitive has focus.
 // implements tview.Focusable
 func (wm *Manager) HasFocus() bool {
-	wm.Lock()
-	defer wm.Unlock()
+	wm.lock()
+	defer wm.unlock()
 	// iterate over all windows. If any has focus, then the
 	// this window manager has focus.
 	return nil != wm.windows.Find(func(wi interface{}) bool {
@@ -164,33 +424,153 @@ func (wm *Manager) HasFocus() bool {
 // Draw draws this primitive onto the screen.
 // implements tview.Primitive.Draw
 func (wm *Manager) Draw(screen tcell.Screen) {
+	wm.runUpdates()
 	wm.Box.Draw(screen)
 
-	wm.Lock()
-	defer wm.Unlock()
+	// draw docked primitives on their reserved strips. They may query the window manager,
+	// for example a taskbar listing its windows, so the window manager is not locked meanwhile
+	for _, d := range wm.drawWindows(screen) {
+		d.primitive.Draw(screen)
+	}
+}
+
+// drawWindows draws the windows within the work area, and returns the docked primitives to draw
+func (wm *Manager) drawWindows(screen tcell.Screen) []dockedPrimitive {
+	wm.lock()
+	defer wm.unlock()
+	wm.sortLayers()
 
-	// Ensure that the window with focus has the highest Z-index:
+	// Ensure that the window with focus has the highest Z-index within its layer:
+	var focused Window
 	topWindowIndex := len(wm.windows) - 1
 	for i := topWindowIndex; i >= 0; i-- {
 		window := wm.windows[i].(Window)
-		if window.IsVisible() && window.HasFocus() {
-			if i < topWindowIndex {
-				wm.setZ(window, WindowZTop) // move focused window on top
+		if onScreen(window) && window.HasFocus() {
+			if !wm.onTop(window) && wm.blocker(window) == nil {
+				wm.setZ(window, WindowZTop) // move focused window on top, unless a modal window blocks it
 			}
+			wm.recordFocus(window)
+			focused = window
 			break
 		}
 	}
 
+	// notify focus changes
+	if focused != wm.focused {
+		if wm.focused != nil {
+			wm.emit(wm.focused, WindowBlur)
+		}
+		if focused != nil {
+			wm.emit(focused, WindowFocus)
+		}
+		wm.focused = focused
+	}
+
 	// make sure windows are not out of bounds, too small,
-	// or too big to fit within the window manager:
+	// or too big to fit within the window manager work area:
+	workArea := wm.layoutDocks()
+	wm.workArea = workArea
+
+	// the background is part of the backdrop behind a modal window
+	if wm.modalBackdrop != nil && wm.topModal() != nil {
+		wm.Box.Draw(NewStyleFilterScreen(NewClipRegion(screen, workArea.X, workArea.Y, workArea.W, workArea.H), wm.modalBackdrop))
+	}
+
+	// windows are kept within the desktop, and arranged within the part of it that is visible
+	wm.setViewport(workArea, wm.viewportX, wm.viewportY)
+	desktop := wm.desktopRect(workArea)
+	view := wm.visibleRect(workArea)
+	mx, my, mw, mh := desktop.Rect()
+	desktopScreen := wm.newDesktopScreen(screen, workArea)
+
+	// place windows shown for the first time
+	wm.place(view)
+
+	// windows move along with their parent
+	wm.followParents()
+	requested := make(map[Window]Rect)
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		requested[window] = NewRect(window.GetRect())
+	}
+	shifts := make(map[Window]Rect) // how far the window manager moves each window below
+
+	// remember the coordinates requested for each window since the last Draw
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		if !onScreen(window) {
+			continue
+		}
+		state := wm.state(window)
+		if rect := NewRect(window.GetRect()); rect != state.applied {
+			state.preferred = rect
+			state.preferredArea = desktop
+		}
+	}
+
+	// tile windows according to the layout
+	var tiled []Window
+	for _, wndItem := range wm.order {
+		window := wndItem.(Window)
+		if onScreen(window) && !isFloating(window) {
+			tiled = append(tiled, window)
+		}
+	}
+	arranged := make(map[Window]bool)
+	rects := wm.layout.Arrange(view, len(tiled))
+	for i, rect := range rects {
+		if i < len(tiled) {
+			tiled[i].SetRect(rect.Rect())
+			arranged[tiled[i]] = true
+		}
+	}
+
+	// fit snapped windows to their zone
+	for window, state := range wm.states {
+		if state.snap != SnapNone && onScreen(window) {
+			rect := state.snap.Rect(view)
+			window.SetRect(rect.Rect())
+			arranged[window] = true
+		}
+	}
+
+	// when tiling, floating windows stay above tiled windows
+	if rects != nil {
+		for _, wndItem := range append(Stack{}, wm.windows...) {
+			if window := wndItem.(Window); isFloating(window) {
+				wm.setZ(window, WindowZTop)
+			}
+		}
+	}
+	// windows stay above their parent
+	wm.raiseChildren()
+
 	for _, wndItem := range wm.windows {
 		window := wndItem.(Window)
-		if !window.IsVisible() {
+		if !onScreen(window) {
 			continue
 		}
-		mx, my, mw, mh := wm.GetInnerRect()
 		x, y, w, h := window.GetRect()
 
+		// bring floating windows back to their preferred coordinates
+		if !arranged[window] {
+			preferred := wm.preferredRect(window, desktop)
+			x, y, w, h = preferred.Rect()
+		}
+
+		// maximized windows take the entire visible work area, as far as their constraints allow
+		if window.IsMaximized() {
+			x, y, w, h = view.Rect()
+		}
+
+		// follow the parent when it was moved below, since it comes first in z order
+		if shift, ok := shifts[getParent(window)]; ok && !arranged[window] && !window.IsMaximized() {
+			x, y = x+shift.X, y+shift.Y
+			state := wm.state(window)
+			state.preferred.X += shift.X
+			state.preferred.Y += shift.Y
+		}
+
 		// Avoid window overflowing on the left:
 		if x < mx {
 			x = mx
@@ -201,26 +581,17 @@ func (wm *Manager) Draw(screen tcell.Screen) {
 			y = my
 		}
 
-		// Fix window that is too narrow:
-		if w < MinWindowWidth {
-			w = MinWindowWidth
-		}
-
-		// Fix window that is too short:
-		if h < MinWindowHeight {
-			h = MinWindowHeight
-		}
+		// Fix window size according to its constraints
+		w, h = sizeConstraints(window).Constrain(w, h)
 
-		// reduce windows that are too wide,
-		// or fix size if the window is maximized
-		if w > mw || window.IsMaximized() {
+		// reduce windows that are too wide
+		if w > mw {
 			w = mw
 			x = mx
 		}
 
-		// reduce windows that are too tall,
-		// or fix size if the window is maximized
-		if h > mh || window.IsMaximized() {
+		// reduce windows that are too tall
+		if h > mh {
 			h = mh
 			y = my
 		}
@@ -237,83 +608,195 @@ func (wm *Manager) Draw(screen tcell.Screen) {
 
 		// reposition window to the new coordinates:
 		window.SetRect(x, y, w, h)
+		applied := NewRect(window.GetRect())
+		wm.state(window).applied = applied
+		if from := requested[window]; applied.X != from.X || applied.Y != from.Y {
+			shifts[window] = Rect{X: applied.X - from.X, Y: applied.Y - from.Y}
+		}
 
-		// now we can draw it
-		window.Draw(screen)
+		// now we can draw it. Windows blocked by a modal window are drawn through the backdrop filter
+		if wm.modalBackdrop != nil && wm.blocker(window) != nil {
+			window.Draw(NewStyleFilterScreen(desktopScreen, wm.modalBackdrop))
+		} else {
+			window.Draw(desktopScreen)
+		}
 	}
+
+	// preview where the dragged window is about to snap
+	if wm.draggedWindow != nil && wm.snapPreview != SnapNone {
+		drawOutline(desktopScreen, wm.snapPreview.Rect(view), tcell.StyleDefault.Foreground(tview.Styles.TertiaryTextColor))
+	}
+
+	wm.drawMoveResize(desktopScreen)
+	wm.drawSwitcher(screen)
+
+	return append([]dockedPrimitive(nil), wm.docked...)
 }
 
 // MouseHandler returns the mouse handler for this primitive.
 // implements tview.Primitive.MouseHandler
 func (wm *Manager) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
 	return wm.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		wm.lock()
+		// windows use desktop coordinates, docked primitives use screen coordinates
+		screenEvent := event
+		sx, sy := event.Position()
+		event = wm.toDesktop(event)
+
+		// send mouse events to the primitive that captured the mouse, if any
+		if capture := wm.mouseCapture; capture != nil {
+			wm.unlock()
+			consumed, capture = capture.MouseHandler()(action, event, setFocus)
+			return consumed, wm.captureMouse(capture)
+		}
+
 		// ignore mouse events out of the bounds of the window manager
-		if !wm.InRect(event.Position()) {
+		if !wm.InRect(sx, sy) {
+			wm.unlock()
 			return false, nil
 		}
-		wm.Lock()
+		wm.mouseX, wm.mouseY = event.Position()
 
 		// check if there is an active drag operation:
 		if wm.draggedWindow != nil {
 			switch action {
 			case tview.MouseLeftUp:
+				// snap the window if it was released on an edge
+				if wm.snapPreview != SnapNone {
+					wm.snap(wm.draggedWindow, wm.snapPreview)
+					wm.snapPreview = SnapNone
+				}
+				wm.pushUndo([]geometry{wm.dragStart})
 				wm.draggedWindow = nil // if the button is released, stop the drag operation
 			case tview.MouseMove:
 				x, y := event.Position()
 				wx, wy, ww, wh := wm.draggedWindow.GetRect()
+				state := wm.state(wm.draggedWindow)
 				// depending if the drag operation is on the top or edges, either move the window or resize
-				if wm.draggedEdge == EdgeTop && wm.draggedWindow.IsDraggable() {
+				if wm.draggedEdge == EdgeNone && wm.draggedWindow.IsDraggable() {
+					if state.snap != SnapNone {
+						// dragging a snapped window away brings back its former size
+						state.snap = SnapNone
+						ww, wh = state.snapRestore.W, state.snapRestore.H
+						if wm.dragOffsetX >= ww {
+							wm.dragOffsetX = ww / 2
+						}
+					}
+					// dragging a window against an edge pans the viewport, if the desktop goes on
+					panned := wm.panAtEdge(sx, sy)
+					if panned {
+						x, y = sx+wm.viewportX, sy+wm.viewportY
+					}
 					wm.draggedWindow.SetRect(x-wm.dragOffsetX, y-wm.dragOffsetY, ww, wh) // move window
+					if wm.snapToEdges {
+						wm.snapPreview = SnapNone
+						if !panned {
+							wm.snapPreview = snapZoneAt(wm.workArea, sx, sy)
+						}
+					}
 				} else {
 					// resize window pulling from the corresponding edge
 					if wm.draggedWindow.IsResizable() {
+						state.snap = SnapNone // resizing a snapped window keeps its current size
+						nw, nh := ww, wh
 						switch wm.draggedEdge {
+						case EdgeTop:
+							nh = wh + wy - y
+						case EdgeTopLeft:
+							nw, nh = ww+wx-x, wh+wy-y
+						case EdgeTopRight:
+							nw, nh = x-wx+1, wh+wy-y
 						case EdgeRight:
-							wm.draggedWindow.SetRect(wx, wy, x-wx+1, wh)
+							nw = x - wx + 1
 						case EdgeBottom:
-							wm.draggedWindow.SetRect(wx, wy, ww, y-wy+1)
+							nh = y - wy + 1
 						case EdgeLeft:
-							wm.draggedWindow.SetRect(x, wy, ww+wx-x, wh)
+							nw = ww + wx - x
 						case EdgeBottomRight:
-							wm.draggedWindow.SetRect(wx, wy, x-wx+1, y-wy+1)
+							nw, nh = x-wx+1, y-wy+1
 						case EdgeBottomLeft:
-							wm.draggedWindow.SetRect(x, wy, ww+wx-x, y-wy+1)
+							nw, nh = ww+wx-x, y-wy+1
+						}
+						nw, nh = sizeConstraints(wm.draggedWindow).constrain(nw, nh, wm.draggedEdge)
+						// keep the opposite edge in place when pulling from the left or the top
+						nx, ny := wx, wy
+						switch wm.draggedEdge {
+						case EdgeLeft, EdgeTopLeft, EdgeBottomLeft:
+							nx = wx + ww - nw
 						}
+						switch wm.draggedEdge {
+						case EdgeTop, EdgeTopLeft, EdgeTopRight:
+							ny = wy + wh - nh
+						}
+						wm.draggedWindow.SetRect(nx, ny, nw, nh)
 					}
 				}
-				wm.Unlock()
+				wm.unlock()
 				return true, nil
 			}
 		}
 
+		// pass mouse events on reserved edges to the docked primitives
+		for _, d := range wm.docked {
+			if handler := d.primitive.MouseHandler(); handler != nil && NewRect(d.primitive.GetRect()).Contains(sx, sy) {
+				wm.unlock()
+				return handler(action, screenEvent, setFocus)
+			}
+		}
+		x, y := event.Position()
+
 		lastModal := false
+		blocked := make(map[Window]Window) // windows blocked by a sheet, and the sheet
 		// Pass mouse events along to the window with highest Z
 		// that is hit by the mouse
 		// Stop if the last window was a modal.
 		for i := len(wm.windows) - 1; i >= 0 && !lastModal; i-- {
 			window := wm.windows[i].(Window)
-			if !window.IsVisible() { // skip hidden windows
+			if !onScreen(window) { // skip hidden and minimized windows
 				continue
 			}
 
 			// if this is a modal window, then don't give a chance for
-			// other windows to get mouse events
-			lastModal = window.IsModal() // if true, will exit loop on the next iteration
+			// other windows to get mouse events.
+			// Modal windows with a parent only block their parent
+			if isSheet(window) {
+				if parent := getParent(window); blocked[parent] == nil {
+					blocked[parent] = window
+				}
+			} else {
+				lastModal = window.IsModal() // if true, will exit loop on the next iteration
+			}
 
-			x, y := event.Position()
 			if !inRect(window, x, y) {
 				// skip this window since it is not hit
 				continue
 			}
 
+			// clicking a window blocked by a sheet brings the sheet to the front
+			if sheet := blocked[window]; sheet != nil {
+				wm.unlock()
+				if action == tview.MouseLeftDown {
+					setFocus(sheet)
+				}
+				return true, nil
+			}
+
 			if action == tview.MouseLeftDown && window.HasBorder() {
 				// initiate a drag operation
 				if !window.HasFocus() {
+					// focus requests consult the window manager, so it must not be locked
+					wm.unlock()
 					setFocus(window)
+					wm.lock()
 				}
 				wx, wy, ww, wh := window.GetRect()
 				wm.draggedEdge = EdgeNone
+				drag := true
 				switch {
+				case y == wy && x == wx:
+					wm.draggedEdge = EdgeTopLeft
+				case y == wy && x == wx+ww-1:
+					wm.draggedEdge = EdgeTopRight
 				case y == wy+wh-1:
 					switch {
 					case x == wx:
@@ -328,32 +811,101 @@ func (wm *Manager) MouseHandler() func(action tview.MouseAction, event *tcell.Ev
 				case x == wx+ww-1:
 					wm.draggedEdge = EdgeRight
 				case y == wy:
-					wm.draggedEdge = EdgeTop
+					// the title bar moves the window, unless the top resize modifiers are held
+					if wm.topResizeModifiers != tcell.ModNone && event.Modifiers()&wm.topResizeModifiers == wm.topResizeModifiers {
+						wm.draggedEdge = EdgeTop
+					}
+				default:
+					drag = false
 				}
-				if wm.draggedEdge != EdgeNone {
+				if drag {
 					// drag detected. Remember where the drag operation started
 					wm.draggedWindow = window
+					wm.dragStart = wm.geometryOf(window)
 					wm.dragOffsetX = x - wx
 					wm.dragOffsetY = y - wy
-					wm.Unlock()
+					wm.unlock()
 					return true, nil
 				}
 			}
-			wm.Unlock()
+			wm.unlock()
 			// no drag operation detected.
 			// pass the mouse events to the window itself.
-			return window.MouseHandler()(action, event, setFocus)
+			consumed, capture = window.MouseHandler()(action, event, setFocus)
+			wm.focusNextIfMinimized(window, setFocus)
+			return consumed, wm.captureMouse(capture)
+		}
+
+		// scrolling the mouse wheel on the background pans the viewport
+		if wm.workArea.Contains(sx, sy) {
+			consumed = true
+			switch action {
+			case tview.MouseScrollUp:
+				wm.pan(0, -PanStepY)
+			case tview.MouseScrollDown:
+				wm.pan(0, PanStepY)
+			case tview.MouseScrollLeft:
+				wm.pan(-PanStepX, 0)
+			case tview.MouseScrollRight:
+				wm.pan(PanStepX, 0)
+			default:
+				consumed = false
+			}
 		}
-		wm.Unlock()
+		wm.unlock()
 
 		return
 	})
 }
 
+// captureMouse keeps the primitive within a window that captured the mouse, so the window
+// manager can keep passing it mouse events in desktop coordinates. Returns the primitive
+// the application must send mouse events to
+func (wm *Manager) captureMouse(capture tview.Primitive) tview.Primitive {
+	wm.lock()
+	defer wm.unlock()
+	wm.mouseCapture = capture
+	if capture == nil {
+		return nil
+	}
+	return wm
+}
+
 // InputHandler returns a handler which receives key events when it has focus.
 func (wm *Manager) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
 	return wm.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
-		wm.Lock()
+		wm.lock()
+		// While the window switcher is open, keys are for the window manager
+		if wm.switcherWindows != nil {
+			window := wm.switcherKey(event)
+			wm.unlock()
+			if window != nil {
+				setFocus(window)
+			}
+			return
+		}
+		if wm.switcherHotkey.Matches(event) {
+			wm.openSwitcher()
+			wm.unlock()
+			return
+		}
+
+		// While moving or resizing a window with the keyboard, keys are for the window manager.
+		// The mode ends if the window was hidden or minimized meanwhile
+		if wm.moveResizeWindow != nil && !onScreen(wm.moveResizeWindow) {
+			wm.moveResizeWindow = nil
+		}
+		if wm.moveResizeWindow != nil {
+			wm.moveResizeKey(event)
+			wm.unlock()
+			return
+		}
+
+		if wm.panKey(event) {
+			wm.unlock()
+			return
+		}
+
 		// Pass key events along to the window with highest Z that is visible and has focus
 		var window Window
 		for i := len(wm.windows) - 1; i >= 0; i-- {
@@ -363,12 +915,34 @@ func (wm *Manager) InputHandler() func(event *tcell.EventKey, setFocus func(p tv
 			}
 			window = nil
 		}
-		wm.Unlock()
+		// keys for a window blocked by a modal window send focus back to the modal window
+		if window != nil {
+			if blocker := wm.blocker(window); blocker != nil {
+				wm.unlock()
+				setFocus(blocker)
+				return
+			}
+		}
+		if window != nil && wm.moveResizeHotkey.Matches(event) {
+			wm.beginMoveResize(window)
+			wm.unlock()
+			return
+		}
+		wm.unlock()
 		if window != nil {
 			inputHandler := window.InputHandler()
 			if inputHandler != nil {
 				inputHandler(event, setFocus)
 			}
+			wm.focusNextIfMinimized(window, setFocus)
 		}
 	})
 }
+
+// focusNextIfMinimized moves focus to the next window if the given
+// window was minimized as a result of handling an event
+func (wm *Manager) focusNextIfMinimized(window Window, setFocus func(p tview.Primitive)) {
+	if window.IsMinimized() {
+		wm.Focus(setFocus)
+	}
+}
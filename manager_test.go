@@ -654,3 +654,180 @@ func TestModal(t *testing.T) {
 	}
 
 }
+
+func TestMinimize(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	wndA := wm.NewWindow().SetRoot(NewBoringPrimitive('A')).Show()
+	wndA.SetRect(0, 0, 10, 10)
+	wndB := wm.NewWindow().SetRoot(NewBoringPrimitive('B')).Show()
+	wndB.SetRect(5, 5, 10, 10)
+	minimizeButton := &winman.Button{
+		Symbol:    '_',
+		Alignment: winman.ButtonRight,
+		OnClick:   func() { wndB.Minimize() },
+	}
+	wndB.AddButton(minimizeButton)
+
+	setFocus(wndB)
+	wm.Draw(screen)
+	sm.Sync()
+	if sm.Char(7, 7) != "B" {
+		t.Fatalf("Expected wndB to be drawn on top, got %q", sm.Char(7, 7))
+	}
+
+	// minimize wndB by clicking its title bar button.
+	// Focus must move on to wndA
+	wm.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(12, 5, tcell.Button1, tcell.ModNone), setFocus)
+	if !wndB.IsMinimized() {
+		t.Fatal("Expected wndB to be minimized after clicking the minimize button")
+	}
+	if wndB.HasFocus() {
+		t.Fatal("Expected a minimized window to not have focus")
+	}
+	if !wndA.HasFocus() {
+		t.Fatal("Expected wndA to get focus after minimizing wndB")
+	}
+
+	// wndB must not be drawn anymore
+	screen.Clear()
+	wm.Draw(screen)
+	sm.Sync()
+	if sm.Char(12, 12) == "B" {
+		t.Fatal("Expected minimized wndB to not be drawn")
+	}
+	if sm.Char(7, 7) != "A" {
+		t.Fatalf("Expected wndA to be visible where wndB was, got %q", sm.Char(7, 7))
+	}
+
+	// minimized windows do not receive mouse events
+	clicked := false
+	wndB.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		clicked = true
+		return action, event
+	})
+	wm.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(12, 12, tcell.Button1, tcell.ModNone), setFocus)
+	if clicked {
+		t.Fatal("Expected minimized wndB to not receive mouse events")
+	}
+
+	// setting the focus on the window manager must skip minimized windows
+	setFocus(wm)
+	if !wndA.HasFocus() {
+		t.Fatal("Expected wndA to get focus, since wndB is minimized")
+	}
+
+	// restoring wndB brings it back to its former rect and z index
+	z := wm.GetZ(wndB)
+	wndB.Restore()
+	wm.Draw(screen)
+	if wndB.IsMinimized() {
+		t.Fatal("Expected wndB to not be minimized after restoring")
+	}
+	if rect := winman.NewRect(wndB.GetRect()); rect != winman.NewRect(5, 5, 10, 10) {
+		t.Fatalf("Expected wndB to be restored at its former rect, got %s", rect)
+	}
+	if wm.GetZ(wndB) != z {
+		t.Fatalf("Expected wndB to keep z index %d, got %d", z, wm.GetZ(wndB))
+	}
+
+	// focusing a minimized window restores it
+	wndB.Minimize()
+	setFocus(wndB)
+	if wndB.IsMinimized() || !wndB.HasFocus() {
+		t.Fatal("Expected wndB to be restored and focused after giving it focus")
+	}
+}
+
+func TestWindowLookup(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wndA := wm.NewWindow().SetID("a").Show()
+	wndB := wm.NewWindow().SetID("b")
+	wndC := wm.NewWindow().SetID("c").Show()
+
+	if wm.WindowByID("b") != wndB {
+		t.Fatal("Expected WindowByID to find wndB")
+	}
+	if wm.WindowByID("z") != nil {
+		t.Fatal("Expected WindowByID to return nil for an unknown id")
+	}
+
+	// ids are stable when z indices change
+	wm.SetZ(wndA, winman.WindowZTop)
+	if wm.WindowByID("a") != wndA {
+		t.Fatal("Expected WindowByID to find wndA after changing its z index")
+	}
+
+	visible := wm.FindWindows(func(window winman.Window) bool {
+		return window.IsVisible()
+	})
+	if len(visible) != 2 || visible[0] != wndC || visible[1] != wndA {
+		t.Fatalf("Expected to find the visible windows in z order, got %v", visible)
+	}
+
+	// the iterator goes from bottom to top and stops when asked to,
+	// while allowing the window manager to be modified
+	var ids []string
+	wm.ForEachWindow(func(window winman.Window) bool {
+		wm.SetZ(window, winman.WindowZBottom)
+		ids = append(ids, window.(*winman.WindowBase).GetID())
+		return len(ids) < 2
+	})
+	if len(ids) != 2 || ids[0] != "b" || ids[1] != "c" {
+		t.Fatalf("Expected to iterate over b and c, got %v", ids)
+	}
+}
+
+func TestTopResize(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	wnd := wm.NewWindow().Show().SetDraggable(true).SetResizable(true)
+	wnd.SetRect(10, 10, 10, 5)
+
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	handler := wm.MouseHandler()
+	drag := func(fromX, fromY, toX, toY int, mod tcell.ModMask) Rect {
+		wm.Draw(screen)
+		handler(tview.MouseLeftDown, tcell.NewEventMouse(fromX, fromY, tcell.Button1, mod), setFocus)
+		handler(tview.MouseMove, tcell.NewEventMouse(toX, toY, tcell.Button1, mod), setFocus)
+		handler(tview.MouseLeftUp, tcell.NewEventMouse(toX, toY, tcell.ButtonNone, mod), setFocus)
+		wm.Draw(screen)
+		return winman.NewRect(wnd.GetRect())
+	}
+
+	// top corners resize the window
+	if rect := drag(10, 10, 8, 7, tcell.ModNone); rect != (Rect{8, 7, 12, 8}) {
+		t.Fatalf("Expected dragging the top left corner to resize the window, got %s", rect)
+	}
+	if rect := drag(19, 7, 24, 5, tcell.ModNone); rect != (Rect{8, 5, 17, 10}) {
+		t.Fatalf("Expected dragging the top right corner to resize the window, got %s", rect)
+	}
+
+	// the title bar moves the window, even with modifiers, until top resize modifiers are set
+	if rect := drag(12, 5, 12, 3, tcell.ModAlt); rect != (Rect{8, 3, 17, 10}) {
+		t.Fatalf("Expected dragging the title bar to move the window, got %s", rect)
+	}
+	wm.SetTopResizeModifiers(tcell.ModAlt)
+	if wm.GetTopResizeModifiers() != tcell.ModAlt {
+		t.Fatal("Expected top resize modifiers to be set")
+	}
+	if rect := drag(12, 3, 12, 1, tcell.ModNone); rect != (Rect{8, 1, 17, 10}) {
+		t.Fatalf("Expected dragging the title bar without modifiers to move the window, got %s", rect)
+	}
+	if rect := drag(12, 1, 12, 4, tcell.ModAlt); rect != (Rect{8, 4, 17, 7}) {
+		t.Fatalf("Expected dragging the title bar with modifiers to resize the window, got %s", rect)
+	}
+}
@@ -0,0 +1,109 @@
+package winman
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Minimap is a primitive that shows a scaled down view of the desktop of a window manager,
+// with its windows and the part of the desktop shown by the viewport.
+// Clicking or dragging on the minimap pans the viewport to that part of the desktop.
+type Minimap struct {
+	*tview.Box
+	manager *Manager // window manager whose desktop is shown
+}
+
+// NewMinimap creates a new minimap bound to the given window manager
+func NewMinimap(manager *Manager) *Minimap {
+	return &Minimap{
+		Box:     tview.NewBox(),
+		manager: manager,
+	}
+}
+
+// scale converts a distance on the desktop to a distance on the minimap
+func scale(value, from, to int) int {
+	if from <= 0 {
+		return 0
+	}
+	return value * to / from
+}
+
+// Draw draws this primitive onto the screen.
+// implements tview.Primitive.Draw
+func (mm *Minimap) Draw(screen tcell.Screen) {
+	mm.Box.DrawForSubclass(screen, mm)
+	x, y, width, height := mm.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	wm := mm.manager
+	wm.Lock()
+	desktop := wm.desktopRect(wm.workArea)
+	view := wm.visibleRect(wm.workArea)
+	var windows []Rect
+	focused := -1
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		if onScreen(window) {
+			if window == wm.focused {
+				focused = len(windows)
+			}
+			windows = append(windows, NewRect(window.GetRect()))
+		}
+	}
+	wm.Unlock()
+
+	// toMinimap converts a rect on the desktop to a rect on the minimap, at least one cell large
+	toMinimap := func(r Rect) Rect {
+		left := x + scale(r.X-desktop.X, desktop.W, width)
+		top := y + scale(r.Y-desktop.Y, desktop.H, height)
+		right := x + scale(r.X+r.W-desktop.X, desktop.W, width)
+		bottom := y + scale(r.Y+r.H-desktop.Y, desktop.H, height)
+		return Rect{X: left, Y: top, W: max(1, right-left), H: max(1, bottom-top)}
+	}
+
+	for i, window := range windows {
+		r := toMinimap(window)
+		ch, color := '░', tview.Styles.SecondaryTextColor
+		if i == focused {
+			ch, color = '▓', tview.Styles.PrimaryTextColor
+		}
+		for wx := r.X; wx < r.X+r.W && wx < x+width; wx++ {
+			for wy := r.Y; wy < r.Y+r.H && wy < y+height; wy++ {
+				screen.SetContent(wx, wy, ch, nil, tcell.StyleDefault.Foreground(color).Background(mm.GetBackgroundColor()))
+			}
+		}
+	}
+	drawOutline(NewClipRegion(screen, x, y, width, height), toMinimap(view), tcell.StyleDefault.Foreground(tview.Styles.TertiaryTextColor))
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+// implements tview.Primitive.MouseHandler
+func (mm *Minimap) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return mm.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		mx, my := event.Position()
+		if !mm.InRect(mx, my) {
+			return false, nil
+		}
+		if action != tview.MouseLeftDown && action != tview.MouseLeftClick && !(action == tview.MouseMove && event.Buttons()&tcell.Button1 != 0) {
+			return true, nil
+		}
+		x, y, width, height := mm.GetInnerRect()
+		if width <= 0 || height <= 0 {
+			return true, nil
+		}
+
+		// center the viewport on the desktop point under the mouse
+		wm := mm.manager
+		wm.lock()
+		defer wm.unlock()
+		area := wm.layoutDocks()
+		desktop := wm.desktopRect(area)
+		dx := scale(mx-x, width, desktop.W)
+		dy := scale(my-y, height, desktop.H)
+		wm.setViewport(area, dx-area.W/2, dy-area.H/2)
+		return true, nil
+	})
+}
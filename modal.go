@@ -0,0 +1,69 @@
+package winman
+
+// topModal returns the topmost modal window on screen that blocks the whole desktop,
+// that is, a modal window without a parent
+func (wm *Manager) topModal() Window {
+	modal, _ := wm.windows.Find(func(wi interface{}) bool {
+		window := wi.(Window)
+		return onScreen(window) && window.IsModal() && getParent(window) == nil
+	}).(Window)
+	return modal
+}
+
+// sheetOf returns the topmost modal window on screen whose parent is the given window
+func (wm *Manager) sheetOf(window Window) Window {
+	sheet, _ := wm.windows.Find(func(wi interface{}) bool {
+		child := wi.(Window)
+		return onScreen(child) && isSheet(child) && getParent(child) == window
+	}).(Window)
+	return sheet
+}
+
+// blocker returns the modal window that must get input instead of the given window,
+// or nil if the given window is not blocked by any modal window.
+// A modal window without a parent blocks the windows below it,
+// while a modal window with a parent only blocks its parent
+func (wm *Manager) blocker(window Window) Window {
+	target := window
+	for i := 0; i < maxParentDepth; i++ {
+		modal := wm.topModal()
+		if modal != nil && target != modal && wm.windows.IndexOf(target) < wm.windows.IndexOf(modal) {
+			target = modal
+		} else if sheet := wm.sheetOf(target); sheet != nil {
+			target = sheet
+		} else {
+			break
+		}
+	}
+	if target == window {
+		return nil
+	}
+	return target
+}
+
+// focusTarget returns the window that must get focus when focus is requested
+// for the given window, which is the given window itself unless it is blocked by a modal window
+func (wm *Manager) focusTarget(window Window) Window {
+	wm.lock()
+	defer wm.unlock()
+	if blocker := wm.blocker(window); blocker != nil {
+		return blocker
+	}
+	return window
+}
+
+// ModalStack returns the modal windows on screen, topmost first.
+// Modal windows without a parent block the windows below them,
+// while modal windows with a parent only block their parent.
+// Focus requested for a blocked window goes to the modal window blocking it
+func (wm *Manager) ModalStack() []Window {
+	wm.lock()
+	defer wm.unlock()
+	var modals []Window
+	for i := len(wm.windows) - 1; i >= 0; i-- {
+		if window := wm.windows[i].(Window); onScreen(window) && window.IsModal() {
+			modals = append(modals, window)
+		}
+	}
+	return modals
+}
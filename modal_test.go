@@ -0,0 +1,135 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestModalFocus(t *testing.T) {
+	wm := winman.NewWindowManager().SetSwitcherHotkey(winman.Hotkey{Key: tcell.KeyF2})
+	wm.SetRect(0, 0, 80, 40)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 40)
+	screen.Init()
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	input := wm.InputHandler()
+
+	field := tview.NewInputField()
+	wndA := wm.NewWindow().Show().SetRoot(field)
+	wndA.SetRect(0, 0, 20, 10)
+	wndB := wm.NewWindow().Show()
+	wndB.SetRect(30, 0, 20, 10)
+	modal := wm.NewWindow().Show().SetModal(true)
+	modal.SetRect(10, 10, 20, 10)
+	wm.Draw(screen)
+
+	if modals := wm.ModalStack(); len(modals) != 1 || modals[0] != modal {
+		t.Fatalf("Expected the modal window to be reported as blocking, got %v", modals)
+	}
+
+	// focus requests for windows below the modal window go to the modal window
+	setFocus(wndA)
+	wm.Draw(screen)
+	if !modal.HasFocus() || wndA.HasFocus() {
+		t.Fatal("Expected focus to go to the modal window")
+	}
+	if wm.GetZ(modal) != 2 {
+		t.Fatal("Expected the modal window to stay on top")
+	}
+
+	// keys for a blocked window send focus back to the modal window
+	setFocus(field)
+	input(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), setFocus)
+	if field.GetText() != "" || !modal.HasFocus() {
+		t.Fatalf("Expected the key to be held back and focus to go back to the modal window, got %q", field.GetText())
+	}
+
+	// the switcher only lists windows that are not blocked
+	input(tcell.NewEventKey(tcell.KeyF2, 0, tcell.ModNone), setFocus)
+	input(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	if !modal.HasFocus() {
+		t.Fatal("Expected the switcher not to move focus below the modal window")
+	}
+
+	// once the modal window closes, focus can move freely again
+	modal.Hide()
+	setFocus(wndA)
+	input(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), setFocus)
+	if field.GetText() != "x" {
+		t.Fatalf("Expected the key to reach the window, got %q", field.GetText())
+	}
+	if modals := wm.ModalStack(); len(modals) != 0 {
+		t.Fatalf("Expected no modal windows, got %v", modals)
+	}
+
+	// sheets only block their parent
+	sheet := wm.NewWindow().Show().SetModal(true).SetParent(wndA)
+	wm.Draw(screen)
+	setFocus(wndA)
+	if !sheet.HasFocus() {
+		t.Fatal("Expected focus to go to the sheet of the window")
+	}
+	setFocus(wndB)
+	if !wndB.HasFocus() {
+		t.Fatal("Expected the sheet not to block other windows")
+	}
+}
+
+func TestModalBackdrop(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+
+	wnd := wm.NewWindow().Show().SetRoot(NewBoringPrimitive('#'))
+	wnd.SetRect(0, 0, 10, 10)
+	modal := wm.NewWindow().Show().SetModal(true).SetRoot(NewBoringPrimitive('M'))
+	modal.SetRect(20, 0, 10, 10)
+	isDim := func(x, y int) bool {
+		_, _, style, _ := screen.GetContent(x, y)
+		_, _, attr := style.Decompose()
+		return attr&tcell.AttrDim != 0
+	}
+
+	// without a backdrop, blocked windows are drawn as usual
+	wm.Draw(screen)
+	if wm.GetModalBackdrop() != nil || isDim(2, 2) {
+		t.Fatal("Expected no backdrop by default")
+	}
+
+	wm.SetModalBackdrop(winman.DimStyle)
+	wm.Draw(screen)
+	if !isDim(2, 2) || !isDim(15, 15) {
+		t.Fatal("Expected the window and the background below the modal window to be dimmed")
+	}
+	if isDim(22, 2) {
+		t.Fatal("Expected the modal window not to be dimmed")
+	}
+
+	// sheets only dim their parent
+	modal.Hide()
+	sheet := wm.NewWindow().Show().SetModal(true).SetParent(wnd)
+	sheet.SetRect(2, 2, 4, 4)
+	other := wm.NewWindow().Show().SetRoot(NewBoringPrimitive('O'))
+	other.SetRect(30, 10, 10, 10)
+	screen.Clear()
+	wm.Draw(screen)
+	if !isDim(8, 8) || isDim(3, 3) || isDim(32, 12) || isDim(15, 15) {
+		t.Fatal("Expected only the parent of the sheet to be dimmed")
+	}
+
+	// other filters transform colors
+	gray := winman.DesaturateStyle(tcell.StyleDefault.Foreground(tcell.ColorRed))
+	if fg, _, _ := gray.Decompose(); fg != tcell.NewRGBColor(76, 76, 76) {
+		t.Fatalf("Expected red to turn gray, got %v", fg)
+	}
+	overlay := winman.OverlayStyle(tcell.ColorGray, tcell.ColorBlack)(tcell.StyleDefault)
+	if fg, bg, _ := overlay.Decompose(); fg != tcell.ColorGray || bg != tcell.ColorBlack {
+		t.Fatal("Expected the overlay colors to be applied")
+	}
+}
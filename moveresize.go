@@ -0,0 +1,116 @@
+package winman
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SetMoveResizeHotkey sets the key combination that lets the user move and resize
+// the focused window with the keyboard. See BeginMoveResize
+func (wm *Manager) SetMoveResizeHotkey(hotkey Hotkey) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.moveResizeHotkey = hotkey
+	return wm
+}
+
+// GetMoveResizeHotkey returns the key combination that starts moving and resizing
+// the focused window with the keyboard
+func (wm *Manager) GetMoveResizeHotkey() Hotkey {
+	wm.lock()
+	defer wm.unlock()
+	return wm.moveResizeHotkey
+}
+
+// BeginMoveResize starts moving and resizing the given window with the keyboard,
+// for example from a window button. While in this mode, the arrow keys move the window,
+// Shift + arrow keys resize it, Enter confirms the new coordinates and
+// Esc brings the window back to where it was.
+func (wm *Manager) BeginMoveResize(window Window) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.beginMoveResize(window)
+	return wm
+}
+
+func (wm *Manager) beginMoveResize(window Window) {
+	if wm.windows.IndexOf(window) == -1 {
+		return
+	}
+	wm.moveResizeWindow = window
+	wm.moveResizeRestore = NewRect(window.GetRect())
+	wm.moveResizeSnap = wm.state(window).snap
+}
+
+// GetMoveResizeWindow returns the window being moved and resized with
+// the keyboard, or nil if there is none
+func (wm *Manager) GetMoveResizeWindow() Window {
+	wm.lock()
+	defer wm.unlock()
+	return wm.moveResizeWindow
+}
+
+// moveResizeKey handles a key press while moving and resizing a window with the keyboard
+func (wm *Manager) moveResizeKey(event *tcell.EventKey) {
+	window := wm.moveResizeWindow
+	state := wm.state(window)
+	x, y, w, h := window.GetRect()
+	dx, dy := 0, 0
+	switch event.Key() {
+	case tcell.KeyUp:
+		dy = -1
+	case tcell.KeyDown:
+		dy = 1
+	case tcell.KeyLeft:
+		dx = -1
+	case tcell.KeyRight:
+		dx = 1
+	case tcell.KeyEnter:
+		wm.pushUndo([]geometry{{window: window, rect: wm.moveResizeRestore, snap: wm.moveResizeSnap}})
+		wm.moveResizeWindow = nil
+		return
+	case tcell.KeyEscape:
+		state.snap = wm.moveResizeSnap
+		window.SetRect(wm.moveResizeRestore.Rect())
+		wm.moveResizeWindow = nil
+		return
+	default:
+		return
+	}
+
+	if event.Modifiers()&tcell.ModShift != 0 {
+		if !window.IsResizable() {
+			return
+		}
+		constraints := sizeConstraints(window)
+		edge := EdgeRight
+		if dx == 0 {
+			edge = EdgeBottom
+		}
+		// grow and shrink by the step size, so the window does not get stuck
+		if constraints.StepWidth > 1 {
+			dx *= constraints.StepWidth
+		}
+		if constraints.StepHeight > 1 {
+			dy *= constraints.StepHeight
+		}
+		w, h = constraints.constrain(w+dx, h+dy, edge)
+	} else {
+		if !window.IsDraggable() {
+			return
+		}
+		x += dx
+		y += dy
+	}
+	state.snap = SnapNone
+	window.SetRect(x, y, w, h)
+}
+
+// drawMoveResize highlights the window being moved and resized with the keyboard
+func (wm *Manager) drawMoveResize(screen tcell.Screen) {
+	window := wm.moveResizeWindow
+	if window == nil || !onScreen(window) {
+		return
+	}
+	drawOutline(screen, NewRect(window.GetRect()), tcell.StyleDefault.Foreground(tview.Styles.TertiaryTextColor))
+}
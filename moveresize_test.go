@@ -0,0 +1,147 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestHotkey(t *testing.T) {
+	if (winman.Hotkey{}).Matches(tcell.NewEventKey(tcell.KeyNUL, 0, tcell.ModNone)) {
+		t.Fatal("Expected the zero hotkey to never match")
+	}
+
+	f7 := winman.Hotkey{Key: tcell.KeyF7}
+	if !f7.Matches(tcell.NewEventKey(tcell.KeyF7, 0, tcell.ModNone)) {
+		t.Fatal("Expected F7 hotkey to match F7")
+	}
+	if f7.Matches(tcell.NewEventKey(tcell.KeyF7, 0, tcell.ModAlt)) {
+		t.Fatal("Expected F7 hotkey to not match Alt+F7")
+	}
+
+	altM := winman.Hotkey{Key: tcell.KeyRune, Rune: 'm', Modifiers: tcell.ModAlt}
+	if !altM.Matches(tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModAlt)) {
+		t.Fatal("Expected Alt+m hotkey to match Alt+m")
+	}
+	if altM.Matches(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModAlt)) {
+		t.Fatal("Expected Alt+m hotkey to not match Alt+n")
+	}
+}
+
+func TestMoveResize(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+
+	wnd := wm.NewWindow().Show().SetDraggable(true).SetResizable(true).SetRoot(&KeyTestPrimitive{})
+	wnd.SetRect(5, 5, 6, 6)
+	wnd.Focus(delegate)
+	wm.Draw(screen)
+
+	inputHandler := wm.InputHandler()
+	press := func(key tcell.Key, mod tcell.ModMask) {
+		inputHandler(tcell.NewEventKey(key, 0, mod), func(tview.Primitive) {})
+	}
+
+	// without a hotkey, keys go to the window
+	lastPrimitive = nil
+	press(tcell.KeyF7, tcell.ModNone)
+	if wm.GetMoveResizeWindow() != nil || lastPrimitive == nil {
+		t.Fatal("Expected key to go to the focused window when no hotkey is configured")
+	}
+
+	wm.SetMoveResizeHotkey(winman.Hotkey{Key: tcell.KeyF7})
+	lastPrimitive = nil
+	press(tcell.KeyF7, tcell.ModNone)
+	if wm.GetMoveResizeWindow() != wnd {
+		t.Fatal("Expected the hotkey to start moving and resizing the focused window")
+	}
+
+	press(tcell.KeyRight, tcell.ModNone)
+	press(tcell.KeyRight, tcell.ModNone)
+	press(tcell.KeyDown, tcell.ModNone)
+	press(tcell.KeyRight, tcell.ModShift)
+	press(tcell.KeyRight, tcell.ModShift)
+	press(tcell.KeyUp, tcell.ModShift)
+	press(tcell.KeyRune, tcell.ModNone) // other keys are ignored
+	if lastPrimitive != nil {
+		t.Fatal("Expected keys to not reach the window while moving and resizing it")
+	}
+	expected := winman.NewRect(7, 6, 8, 5)
+	if rect := winman.NewRect(wnd.GetRect()); rect != expected {
+		t.Fatalf("Expected window to be at %s, got %s", expected, rect)
+	}
+
+	press(tcell.KeyEnter, tcell.ModNone)
+	if wm.GetMoveResizeWindow() != nil {
+		t.Fatal("Expected Enter to finish moving and resizing")
+	}
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != expected {
+		t.Fatalf("Expected window to stay at %s, got %s", expected, rect)
+	}
+
+	// Esc cancels, bringing the window back
+	wm.BeginMoveResize(wnd)
+	press(tcell.KeyLeft, tcell.ModNone)
+	press(tcell.KeyDown, tcell.ModShift)
+	press(tcell.KeyEscape, tcell.ModNone)
+	if wm.GetMoveResizeWindow() != nil {
+		t.Fatal("Expected Esc to finish moving and resizing")
+	}
+	if rect := winman.NewRect(wnd.GetRect()); rect != expected {
+		t.Fatalf("Expected window to be back at %s, got %s", expected, rect)
+	}
+
+	// windows that are not draggable or resizable don't move or resize
+	wnd.SetDraggable(false).SetResizable(false)
+	wm.BeginMoveResize(wnd)
+	press(tcell.KeyLeft, tcell.ModNone)
+	press(tcell.KeyLeft, tcell.ModShift)
+	press(tcell.KeyEnter, tcell.ModNone)
+	if rect := winman.NewRect(wnd.GetRect()); rect != expected {
+		t.Fatalf("Expected fixed window to stay at %s, got %s", expected, rect)
+	}
+
+	// windows that are not part of the window manager are ignored
+	wm.BeginMoveResize(winman.NewWindow())
+	if wm.GetMoveResizeWindow() != nil {
+		t.Fatal("Expected foreign window to not be moved")
+	}
+}
+
+func TestMoveResizeHidden(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+
+	moved := wm.NewWindow().Show().SetDraggable(true)
+	moved.SetRect(0, 0, 6, 6)
+	other := wm.NewWindow().Show().SetRoot(&KeyTestPrimitive{})
+	other.SetRect(8, 8, 6, 6)
+	other.Focus(delegate)
+	wm.Draw(screen)
+
+	inputHandler := wm.InputHandler()
+	press := func(key tcell.Key) {
+		inputHandler(tcell.NewEventKey(key, 0, tcell.ModNone), func(tview.Primitive) {})
+	}
+
+	// hiding the window while moving it ends the mode, and keys go through again
+	wm.BeginMoveResize(moved)
+	moved.Hide()
+	lastPrimitive = nil
+	press(tcell.KeyRight)
+	if wm.GetMoveResizeWindow() != nil || lastPrimitive == nil {
+		t.Fatal("Expected hiding the window to end moving and resizing it")
+	}
+	if rect := winman.NewRect(moved.GetRect()); rect != winman.NewRect(0, 0, 6, 6) {
+		t.Fatalf("Expected the hidden window not to move, got %s", rect)
+	}
+}
@@ -0,0 +1,119 @@
+package winman
+
+// maxParentDepth limits how far up the chain of parents the window manager looks,
+// so a mistaken cycle of parents does not hang it
+const maxParentDepth = 32
+
+// ancestors returns the parent of the given window, the parent of the parent and so on
+func ancestors(window Window) []Window {
+	var chain []Window
+	for parent := getParent(window); parent != nil && len(chain) < maxParentDepth; parent = getParent(parent) {
+		chain = append(chain, parent)
+	}
+	return chain
+}
+
+// isSheet returns true if the window is modal for its parent only
+func isSheet(window Window) bool {
+	return window.IsModal() && getParent(window) != nil
+}
+
+// isDescendant returns true if the given ancestor is the parent of the window,
+// or the parent of its parent and so on
+func isDescendant(window, ancestor Window) bool {
+	for _, parent := range ancestors(window) {
+		if parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// onTop returns true if the given window is on top of its layer,
+// with only its own children and their children above it
+func (wm *Manager) onTop(window Window) bool {
+	_, end := wm.layerRange(getLayer(window))
+	for i := end - 1; i >= 0; i-- {
+		above := wm.windows[i].(Window)
+		if above == window {
+			return true
+		}
+		if !isDescendant(above, window) {
+			return false
+		}
+	}
+	return false
+}
+
+// children returns the windows whose parent is the given window, from the bottom to the top
+func (wm *Manager) children(window Window) []Window {
+	var children []Window
+	for _, wndItem := range wm.windows {
+		if child := wndItem.(Window); getParent(child) == window {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Children returns the windows whose parent is the given window
+func (wm *Manager) Children(window Window) []Window {
+	wm.lock()
+	defer wm.unlock()
+	return wm.children(window)
+}
+
+// followParents moves windows along with their parent, when their parent
+// was moved since the last Draw. Windows that were moved as well, for example
+// by an arrangement command or Undo, keep the position they were given
+func (wm *Manager) followParents() {
+	moved := make(map[Window]Rect)
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		applied := wm.state(window).applied
+		if applied == (Rect{}) {
+			continue
+		}
+		if x, y, _, _ := window.GetRect(); x != applied.X || y != applied.Y {
+			moved[window] = Rect{X: x - applied.X, Y: y - applied.Y}
+		}
+	}
+	if len(moved) == 0 {
+		return
+	}
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		if _, ok := moved[window]; ok {
+			continue
+		}
+		// the nearest ancestor that moved carries the moves of the ones above it
+		for _, parent := range ancestors(window) {
+			if delta, ok := moved[parent]; ok {
+				x, y, w, h := window.GetRect()
+				window.SetRect(x+delta.X, y+delta.Y, w, h)
+				break
+			}
+		}
+	}
+}
+
+// raiseChildren makes sure windows stay above their parent
+func (wm *Manager) raiseChildren() {
+	for pass := 0; pass < maxParentDepth; pass++ {
+		changed := false
+		for _, wndItem := range append(Stack{}, wm.windows...) {
+			window := wndItem.(Window)
+			parent := getParent(window)
+			if parent == nil {
+				continue
+			}
+			if z, parentZ := wm.windows.IndexOf(window), wm.windows.IndexOf(parent); parentZ != -1 && z < parentZ {
+				wm.moveTo(window, parentZ)
+				changed = true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
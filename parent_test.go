@@ -0,0 +1,127 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestSheet(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 80, 40)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 40)
+	screen.Init()
+	handler := wm.MouseHandler()
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	docRoot := NewBoringPrimitive('D')
+	doc := wm.NewWindow().Show().SetRoot(docRoot).SetDraggable(true)
+	doc.SetRect(0, 0, 30, 20)
+	otherRoot := NewBoringPrimitive('O')
+	other := wm.NewWindow().Show().SetRoot(otherRoot)
+	other.SetRect(40, 0, 30, 20)
+	sheet := wm.NewWindow().Show().SetModal(true).SetParent(doc)
+	wm.Draw(screen)
+
+	// the sheet opens centered on its parent and stays above it
+	if rect := winman.NewRect(sheet.GetRect()); rect != (Rect{7, 5, 15, 10}) {
+		t.Fatalf("Expected the sheet to open centered on its parent, got %s", rect)
+	}
+	setFocus(doc)
+	wm.Draw(screen)
+	if wm.GetZ(sheet) < wm.GetZ(doc) {
+		t.Fatal("Expected the sheet to stay above its parent")
+	}
+	if children := wm.Children(doc); len(children) != 1 || children[0] != sheet {
+		t.Fatalf("Expected the sheet to be the only child of the document, got %v", children)
+	}
+
+	// the sheet blocks its parent only
+	handler(tview.MouseLeftClick, tcell.NewEventMouse(2, 2, tcell.Button1, tcell.ModNone), setFocus)
+	if docRoot.clickCount != 0 {
+		t.Fatal("Expected the sheet to block mouse input to its parent")
+	}
+	handler(tview.MouseLeftClick, tcell.NewEventMouse(42, 2, tcell.Button1, tcell.ModNone), setFocus)
+	if otherRoot.clickCount != 1 {
+		t.Fatal("Expected the sheet not to block other windows")
+	}
+	handler(tview.MouseLeftDown, tcell.NewEventMouse(2, 2, tcell.Button1, tcell.ModNone), setFocus)
+	handler(tview.MouseLeftUp, tcell.NewEventMouse(2, 2, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if !sheet.HasFocus() {
+		t.Fatal("Expected clicking on the blocked parent to focus the sheet")
+	}
+
+	// the sheet moves with its parent
+	doc.SetRect(5, 5, 30, 20)
+	wm.Draw(screen)
+	if rect := winman.NewRect(sheet.GetRect()); rect != (Rect{12, 10, 15, 10}) {
+		t.Fatalf("Expected the sheet to move along with its parent, got %s", rect)
+	}
+
+	// the sheet follows where its parent ends up, after being kept within the work area
+	doc.SetRect(60, 30, 30, 20)
+	wm.Draw(screen)
+	if rect := winman.NewRect(sheet.GetRect()); rect != (Rect{57, 25, 15, 10}) {
+		t.Fatalf("Expected the sheet to follow its parent kept within the work area, got %s", rect)
+	}
+	doc.SetRect(5, 5, 30, 20)
+	wm.Draw(screen)
+	if rect := winman.NewRect(sheet.GetRect()); rect != (Rect{12, 10, 15, 10}) {
+		t.Fatalf("Expected the sheet to move back along with its parent, got %s", rect)
+	}
+
+	// the sheet is hidden along with its parent
+	doc.Minimize()
+	screen.Clear()
+	wm.Draw(screen)
+	sm := &ScreenMonitor{screen: screen}
+	sm.Sync()
+	if sm.Char(13, 11) != " " {
+		t.Fatalf("Expected the sheet to be hidden when its parent is minimized, got %q", sm.Char(13, 11))
+	}
+	doc.Restore()
+
+	// closing the parent closes the sheet
+	if !doc.Close() {
+		t.Fatal("Expected the document to close")
+	}
+	if sheet.IsVisible() || wm.WindowCount() != 1 {
+		t.Fatalf("Expected the sheet to be closed with its parent, %d windows left", wm.WindowCount())
+	}
+}
+
+func TestChildArrange(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 80, 40)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 40)
+	screen.Init()
+
+	parent := wm.NewWindow().Show()
+	parent.SetRect(20, 10, 20, 10)
+	child := wm.NewWindow().Show().SetParent(parent)
+	child.SetRect(25, 12, 10, 5)
+	wm.Draw(screen)
+
+	// windows moved along with their parent keep the position they were given
+	for _, arrange := range []func() *winman.Manager{wm.TileVertically, wm.Cascade} {
+		arrange()
+		arranged := winman.NewRect(child.GetRect())
+		wm.Draw(screen)
+		if rect := winman.NewRect(child.GetRect()); rect != arranged {
+			t.Fatalf("Expected the child to stay where it was arranged at %s, got %s", arranged, rect)
+		}
+		wm.Undo()
+		wm.Draw(screen)
+		if rect := winman.NewRect(child.GetRect()); rect != (Rect{25, 12, 10, 5}) {
+			t.Fatalf("Expected undo to bring the child back, got %s", rect)
+		}
+		if rect := winman.NewRect(parent.GetRect()); rect != (Rect{20, 10, 20, 10}) {
+			t.Fatalf("Expected undo to bring the parent back, got %s", rect)
+		}
+	}
+}
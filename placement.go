@@ -0,0 +1,139 @@
+package winman
+
+// Placement defines where the window manager puts windows that are shown
+// for the first time without the application setting their coordinates
+type Placement int16
+
+// Different placement policies
+const (
+	PlacementNone       Placement = iota // windows keep their default coordinates
+	PlacementCascade                     // each new window is placed a bit below and to the right of the previous one
+	PlacementCenter                      // windows are centered in the work area
+	PlacementUnderMouse                  // windows are centered under the last known mouse position
+	PlacementSmart                       // windows are placed where they overlap the least with other windows
+	PlacementParent                      // windows are centered on their parent window, or on the work area if they have none
+)
+
+// cascade offsets between consecutive cascaded windows
+const (
+	cascadeOffsetX = 2
+	cascadeOffsetY = 1
+)
+
+// SetPlacement sets the policy used to place windows that become visible for the first
+// time without the application having set their coordinates. The default is PlacementNone.
+func (wm *Manager) SetPlacement(placement Placement) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.placement = placement
+	return wm
+}
+
+// GetPlacement returns the policy used to place new windows
+func (wm *Manager) GetPlacement() Placement {
+	wm.lock()
+	defer wm.unlock()
+	return wm.placement
+}
+
+// overlap returns the area shared by both rectangles
+func overlap(a, b Rect) int {
+	w := min(a.X+a.W, b.X+b.W) - max(a.X, b.X)
+	h := min(a.Y+a.H, b.Y+b.H) - max(a.Y, b.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// centerOn returns a rect of the given size centered on the given area
+func centerOn(area Rect, w, h int) Rect {
+	return Rect{X: area.X + (area.W-w)/2, Y: area.Y + (area.H-h)/2, W: w, H: h}
+}
+
+// place sets the coordinates of windows that are on screen for the first
+// time and whose position was not set by the application.
+// Windows with a parent are centered on it, whatever the placement policy
+func (wm *Manager) place(area Rect) {
+	for _, wndItem := range wm.windows {
+		window := wndItem.(Window)
+		base := getBase(window)
+		if base == nil || base.explicitPosition || !onScreen(window) {
+			continue
+		}
+		if wm.placement == PlacementNone && base.parent == nil {
+			base.explicitPosition = true
+			continue
+		}
+		rect := wm.placementRect(window, area)
+		window.SetRect(rect.Rect())
+	}
+}
+
+// placementRect returns where a new window must be placed according to the placement policy
+func (wm *Manager) placementRect(window Window, area Rect) Rect {
+	_, _, w, h := window.GetRect()
+	// windows with a parent open centered on it
+	if parent := getParent(window); parent != nil && wm.windows.IndexOf(parent) != -1 && onScreen(parent) {
+		return centerOn(NewRect(parent.GetRect()), w, h)
+	}
+	switch wm.placement {
+	case PlacementCascade:
+		x := area.X + wm.cascadeIndex*cascadeOffsetX
+		y := area.Y + wm.cascadeIndex*cascadeOffsetY
+		if wm.cascadeIndex > 0 && (x+w > area.X+area.W || y+h > area.Y+area.H) {
+			wm.cascadeIndex = 0
+			x, y = area.X, area.Y
+		}
+		wm.cascadeIndex++
+		return Rect{X: x, Y: y, W: w, H: h}
+	case PlacementUnderMouse:
+		return Rect{X: wm.mouseX - w/2, Y: wm.mouseY - h/2, W: w, H: h}
+	case PlacementSmart:
+		return wm.smartRect(window, area, w, h)
+	}
+	return centerOn(area, w, h)
+}
+
+// smartRect returns the position within the work area where a window of the given size
+// overlaps the least with the other windows on screen, preferring the top left
+func (wm *Manager) smartRect(window Window, area Rect, w, h int) Rect {
+	var others []Rect
+	for _, wndItem := range wm.windows {
+		if other := wndItem.(Window); other != window && onScreen(other) {
+			others = append(others, NewRect(other.GetRect()))
+		}
+	}
+	best := Rect{X: area.X, Y: area.Y, W: w, H: h}
+	bestOverlap := -1
+	for y := area.Y; y == area.Y || y+h <= area.Y+area.H; y++ {
+		for x := area.X; x == area.X || x+w <= area.X+area.W; x++ {
+			candidate := Rect{X: x, Y: y, W: w, H: h}
+			total := 0
+			for _, other := range others {
+				total += overlap(candidate, other)
+			}
+			if bestOverlap == -1 || total < bestOverlap {
+				best, bestOverlap = candidate, total
+			}
+			if bestOverlap == 0 {
+				return best
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,75 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestPlacement(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	newManager := func(placement winman.Placement) *winman.Manager {
+		wm := winman.NewWindowManager().SetPlacement(placement)
+		wm.SetRect(0, 0, 40, 20)
+		return wm
+	}
+	newWindow := func(wm *winman.Manager) *winman.WindowBase {
+		wnd := wm.NewWindow().Show()
+		wm.Draw(screen)
+		return wnd
+	}
+	assertRect := func(name string, wnd *winman.WindowBase, expected Rect) {
+		t.Helper()
+		if rect := winman.NewRect(wnd.GetRect()); rect != expected {
+			t.Fatalf("%s: Expected window at %s, got %s", name, expected, rect)
+		}
+	}
+
+	wm := newManager(winman.PlacementNone)
+	if wm.GetPlacement() != winman.PlacementNone {
+		t.Fatal("Expected windows not to be placed by default")
+	}
+	assertRect("none", newWindow(wm), Rect{0, 0, 15, 10})
+
+	wm = newManager(winman.PlacementCenter)
+	assertRect("center", newWindow(wm), Rect{12, 5, 15, 10})
+
+	// windows with explicit coordinates are not placed
+	explicit := wm.NewWindow().Show()
+	explicit.SetRect(1, 2, 15, 10)
+	wm.Draw(screen)
+	assertRect("explicit", explicit, Rect{1, 2, 15, 10})
+
+	// windows with only their size set are placed
+	sized := wm.NewWindow().Show().SetSize(20, 6)
+	wm.Draw(screen)
+	assertRect("sized", sized, Rect{10, 7, 20, 6})
+
+	wm = newManager(winman.PlacementCascade)
+	assertRect("cascade #1", newWindow(wm), Rect{0, 0, 15, 10})
+	assertRect("cascade #2", newWindow(wm), Rect{2, 1, 15, 10})
+
+	wm = newManager(winman.PlacementSmart)
+	assertRect("smart #1", newWindow(wm), Rect{0, 0, 15, 10})
+	assertRect("smart #2", newWindow(wm), Rect{15, 0, 15, 10})
+	assertRect("smart #3", newWindow(wm), Rect{0, 10, 15, 10})
+
+	wm = newManager(winman.PlacementUnderMouse)
+	var focusedPrimitive tview.Primitive
+	wm.MouseHandler()(tview.MouseMove, tcell.NewEventMouse(30, 12, tcell.ButtonNone, tcell.ModNone), Focuser(&focusedPrimitive))
+	assertRect("under mouse", newWindow(wm), Rect{23, 7, 15, 10})
+
+	wm = newManager(winman.PlacementParent)
+	parent := wm.NewWindow().Show()
+	parent.SetRect(20, 6, 19, 12)
+	dialog := wm.NewWindow().SetParent(parent).Show()
+	wm.Draw(screen)
+	assertRect("parent", dialog, Rect{22, 7, 15, 10})
+	if dialog.GetParent() != parent {
+		t.Fatal("Expected dialog to keep its parent")
+	}
+}
@@ -4,10 +4,10 @@ import "fmt"
 
 // Rect represents rectangular coordinates
 type Rect struct {
-	X int // x coordinate
-	Y int // y coordinate
-	W int // width
-	H int // height
+	X int `json:"x"` // x coordinate
+	Y int `json:"y"` // y coordinate
+	W int `json:"w"` // width
+	H int `json:"h"` // height
 }
 
 // NewRect instantiates a new Rect with the given coordinates
@@ -0,0 +1,218 @@
+package winman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WindowFactory creates a window with the given id. It is used by LoadLayout
+// to rebuild windows that are described in a saved layout but do not exist yet.
+// The factory must not add the window to the window manager, LoadLayout does it.
+type WindowFactory func(id string) Window
+
+// windowLayout describes the geometry and state of a window in a saved layout
+type windowLayout struct {
+	ID          string `json:"id"`
+	Type        string `json:"type,omitempty"`
+	Rect        Rect   `json:"rect"`
+	RestoreRect Rect   `json:"restoreRect"`
+	Maximized   bool   `json:"maximized"`
+	Minimized   bool   `json:"minimized"`
+	Visible     bool   `json:"visible"`
+	Modal       bool   `json:"modal"`
+	Layer       Layer  `json:"layer"`
+	Workspace   string `json:"workspace,omitempty"`
+	Sticky      bool   `json:"sticky,omitempty"`
+	Z           int    `json:"z"`
+}
+
+// desktopLayout is the document written by SaveLayout
+type desktopLayout struct {
+	Windows []windowLayout `json:"windows"`
+}
+
+// RegisterWindowType registers a factory to create windows of the given type name
+// when loading a layout. Passing a nil factory removes the registration.
+func (wm *Manager) RegisterWindowType(typeName string, factory WindowFactory) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	if factory == nil {
+		delete(wm.factories, typeName)
+		return wm
+	}
+	if wm.factories == nil {
+		wm.factories = make(map[string]WindowFactory)
+	}
+	wm.factories[typeName] = factory
+	return wm
+}
+
+// SaveLayout writes the id, geometry, state, workspace and z-order of every window as JSON,
+// on every workspace. Windows that are not based on WindowBase or do not have an id are not saved.
+func (wm *Manager) SaveLayout(w io.Writer) error {
+	wm.lock()
+	wm.initWorkspaces()
+	layout := desktopLayout{Windows: []windowLayout{}}
+	for i, ws := range wm.workspaces {
+		stack := ws.windows
+		if i == wm.activeWorkspace {
+			stack = wm.windows
+		}
+		for z, wndItem := range stack {
+			window := wndItem.(Window)
+			base := getBase(window)
+			if base == nil || base.id == "" {
+				continue
+			}
+			layout.Windows = append(layout.Windows, windowLayout{
+				ID:          base.id,
+				Type:        base.typeName,
+				Rect:        NewRect(window.GetRect()),
+				RestoreRect: base.restoreRect,
+				Maximized:   base.maximized,
+				Minimized:   base.minimized,
+				Visible:     base.Visible,
+				Modal:       base.Modal,
+				Layer:       base.layer,
+				Workspace:   ws.name,
+				Sticky:      wm.state(window).sticky,
+				Z:           z,
+			})
+		}
+	}
+	wm.unlock()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(layout)
+}
+
+// LoadLayout reads a layout written by SaveLayout and applies it to the windows
+// with the same id. Windows that do not exist yet are created with the factory
+// registered for their type name. Windows go to their saved workspace, which is
+// added if it does not exist, or else to the active one. If the layout cannot be read
+// or refers to a window that cannot be created, an error is returned and no window is changed.
+// Windows not mentioned in the layout are left untouched, below the loaded ones.
+func (wm *Manager) LoadLayout(r io.Reader) error {
+	var layout desktopLayout
+	if err := json.NewDecoder(r).Decode(&layout); err != nil {
+		return fmt.Errorf("winman: cannot read layout: %w", err)
+	}
+
+	// find the windows to restore and check missing ones can be created
+	wm.lock()
+	windows := make([]Window, len(layout.Windows))
+	factories := make([]WindowFactory, len(layout.Windows))
+	seen := make(map[string]bool)
+	for i, l := range layout.Windows {
+		if l.ID == "" || seen[l.ID] {
+			wm.unlock()
+			return fmt.Errorf("winman: invalid window id %q in layout", l.ID)
+		}
+		seen[l.ID] = true
+		if windows[i] = wm.windowByID(l.ID); windows[i] != nil {
+			continue
+		}
+		if factories[i] = wm.factories[l.Type]; factories[i] == nil {
+			wm.unlock()
+			return fmt.Errorf("winman: cannot restore window %q: no factory registered for type %q", l.ID, l.Type)
+		}
+	}
+	wm.unlock()
+
+	// create missing windows without holding the lock, since factories may call the window manager.
+	// They are only added once all of them were created
+	created := make([]Window, len(layout.Windows))
+	for i, l := range layout.Windows {
+		if windows[i] != nil {
+			continue
+		}
+		window := factories[i](l.ID)
+		if window == nil {
+			return fmt.Errorf("winman: factory for type %q returned no window", l.Type)
+		}
+		if base := getBase(window); base != nil {
+			if base.id == "" {
+				base.id = l.ID
+			}
+			if base.typeName == "" {
+				base.typeName = l.Type
+			}
+		}
+		created[i] = window
+	}
+	for i, window := range created {
+		if window != nil {
+			wm.AddWindow(window)
+			windows[i] = window
+		}
+	}
+
+	wm.lock()
+	defer wm.unlock()
+	for i, l := range layout.Windows {
+		window := windows[i]
+
+		// sticky windows are always on the active workspace
+		to := wm.activeWorkspace
+		if l.Workspace != "" && !l.Sticky {
+			if to = wm.findWorkspace(l.Workspace); to == -1 {
+				wm.workspaces = append(wm.workspaces, &workspace{name: l.Workspace})
+				to = len(wm.workspaces) - 1
+			}
+		}
+		if from := wm.workspaceOf(window); from != to {
+			wm.moveToWorkspace(window, from, to)
+		}
+		wm.state(window).sticky = l.Sticky
+
+		wm.state(window).snap = SnapNone
+		base := getBase(window)
+		if base == nil {
+			window.SetRect(l.Rect.Rect())
+			continue
+		}
+		if base.minimized && !l.Minimized {
+			base.Restore()
+		}
+		if l.Maximized {
+			base.Maximize()
+		} else if base.maximized {
+			base.Restore()
+		}
+		base.SetRect(l.Rect.Rect())
+		base.restoreRect = l.RestoreRect
+		if l.Minimized {
+			base.Minimize()
+		}
+		base.SetModal(l.Modal)
+		base.layer = l.Layer
+		if l.Visible {
+			base.Show()
+		} else {
+			base.Hide()
+		}
+	}
+
+	// raise windows from the bottom of the saved z-order up
+	order := make([]int, len(layout.Windows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return layout.Windows[order[a]].Z < layout.Windows[order[b]].Z
+	})
+	wm.sortLayers()
+	for _, i := range order {
+		window := windows[i]
+		if ws := wm.workspaceOf(window); ws != wm.activeWorkspace {
+			stack := &wm.workspaces[ws].windows
+			stack.Remove(window)
+			stack.Push(window)
+			continue
+		}
+		wm.setZ(window, WindowZTop)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package winman_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+)
+
+func TestSaveLoadLayout(t *testing.T) {
+	wm := winman.NewWindowManager()
+	editor := wm.NewWindow().SetID("editor").SetTypeName("editor").Show()
+	editor.SetRect(2, 3, 30, 10)
+	editor.Maximize()
+	log := wm.NewWindow().SetID("log").SetTypeName("log").Show()
+	log.SetRect(40, 5, 20, 8)
+	wm.NewWindow().Show() // windows without id are not saved
+
+	var buf bytes.Buffer
+	if err := wm.SaveLayout(&buf); err != nil {
+		t.Fatalf("Expected layout to be saved, got %s", err)
+	}
+	saved := buf.String()
+
+	// rects are saved with lowercase field names
+	if !strings.Contains(saved, `"rect": {
+        "x": 2,
+        "y": 3,
+        "w": 30,
+        "h": 10
+      }`) {
+		t.Fatalf("Expected rects to be saved with x, y, w and h fields, got:\n%s", saved)
+	}
+
+	// loading into a new window manager without factories must fail and change nothing
+	wm2 := winman.NewWindowManager()
+	if err := wm2.LoadLayout(strings.NewReader(saved)); err == nil {
+		t.Fatal("Expected an error when a window type has no factory")
+	}
+	if wm2.WindowCount() != 0 {
+		t.Fatalf("Expected no windows to be created on error, got %d", wm2.WindowCount())
+	}
+
+	factory := func(id string) winman.Window {
+		return winman.NewWindow()
+	}
+
+	// a factory failing after another one succeeded must not leave windows behind
+	wm2.RegisterWindowType("editor", factory).RegisterWindowType("log", func(id string) winman.Window {
+		return nil
+	})
+	if err := wm2.LoadLayout(strings.NewReader(saved)); err == nil {
+		t.Fatal("Expected an error when a factory returns no window")
+	}
+	if wm2.WindowCount() != 0 {
+		t.Fatalf("Expected no windows to be added when a factory fails, got %d", wm2.WindowCount())
+	}
+
+	wm2.RegisterWindowType("editor", factory).RegisterWindowType("log", factory)
+	existing := wm2.NewWindow().SetID("log").SetTypeName("log")
+	if err := wm2.LoadLayout(strings.NewReader(saved)); err != nil {
+		t.Fatalf("Expected layout to be loaded, got %s", err)
+	}
+	if wm2.WindowCount() != 2 {
+		t.Fatalf("Expected only the missing window to be created, got %d windows", wm2.WindowCount())
+	}
+	if wm2.Window(1) != existing {
+		t.Fatal("Expected the existing window to be reused and kept on top")
+	}
+	restored, ok := wm2.Window(0).(*winman.WindowBase)
+	if !ok || restored.GetID() != "editor" || restored.GetTypeName() != "editor" {
+		t.Fatal("Expected the editor window to be created with its id and type name")
+	}
+	if !restored.IsMaximized() || !restored.IsVisible() {
+		t.Fatal("Expected the editor window to be restored maximized and visible")
+	}
+	restored.Maximize().Restore()
+	if winman.NewRect(restored.GetRect()) != (Rect{2, 3, 30, 10}) {
+		t.Fatalf("Expected the editor restore rect to be loaded, got %v", winman.NewRect(restored.GetRect()))
+	}
+	if winman.NewRect(existing.GetRect()) != (Rect{40, 5, 20, 8}) || !existing.IsVisible() {
+		t.Fatal("Expected the log window to get its saved rect and visibility")
+	}
+
+	// saving the loaded layout must produce the same document
+	buf.Reset()
+	restored.Maximize()
+	if err := wm2.SaveLayout(&buf); err != nil {
+		t.Fatalf("Expected layout to be saved, got %s", err)
+	}
+	if buf.String() != saved {
+		t.Fatalf("Expected the loaded layout to be saved identically, got:\n%s\nwant:\n%s", buf.String(), saved)
+	}
+
+	if err := wm2.LoadLayout(strings.NewReader("{")); err == nil {
+		t.Fatal("Expected an error when the layout is not valid JSON")
+	}
+}
@@ -0,0 +1,141 @@
+package winman
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SnapZone enumerates the areas of the screen a window can snap to
+type SnapZone int16
+
+// Different snap zones
+const (
+	SnapNone        SnapZone = iota // window is not snapped
+	SnapLeft                        // left half of the work area
+	SnapRight                       // right half of the work area
+	SnapTop                         // entire work area
+	SnapTopLeft                     // top left quarter of the work area
+	SnapTopRight                    // top right quarter of the work area
+	SnapBottomLeft                  // bottom left quarter of the work area
+	SnapBottomRight                 // bottom right quarter of the work area
+)
+
+// Rect returns the coordinates of this zone within the given area
+func (z SnapZone) Rect(area Rect) Rect {
+	halfW, halfH := area.W/2, area.H/2
+	switch z {
+	case SnapLeft:
+		return NewRect(area.X, area.Y, halfW, area.H)
+	case SnapRight:
+		return NewRect(area.X+halfW, area.Y, area.W-halfW, area.H)
+	case SnapTopLeft:
+		return NewRect(area.X, area.Y, halfW, halfH)
+	case SnapTopRight:
+		return NewRect(area.X+halfW, area.Y, area.W-halfW, halfH)
+	case SnapBottomLeft:
+		return NewRect(area.X, area.Y+halfH, halfW, area.H-halfH)
+	case SnapBottomRight:
+		return NewRect(area.X+halfW, area.Y+halfH, area.W-halfW, area.H-halfH)
+	}
+	return area
+}
+
+// snapZoneAt returns the zone a window dragged to the given coordinates snaps to.
+// Windows snap when the mouse reaches the left, right or top edges of the area.
+// Reaching a corner snaps the window to that quarter
+func snapZoneAt(area Rect, x, y int) SnapZone {
+	left := x <= area.X
+	right := x >= area.X+area.W-1
+	top := y <= area.Y
+	bottom := y >= area.Y+area.H-1
+	switch {
+	case left && top:
+		return SnapTopLeft
+	case right && top:
+		return SnapTopRight
+	case left && bottom:
+		return SnapBottomLeft
+	case right && bottom:
+		return SnapBottomRight
+	case left:
+		return SnapLeft
+	case right:
+		return SnapRight
+	case top:
+		return SnapTop
+	}
+	return SnapNone
+}
+
+// SetSnapToEdges sets whether windows dragged to the edges of the work area
+// snap to halves, quarters or the entire work area
+func (wm *Manager) SetSnapToEdges(snap bool) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.snapToEdges = snap
+	return wm
+}
+
+// GetSnapToEdges returns true if windows snap when dragged to the edges of the work area
+func (wm *Manager) GetSnapToEdges() bool {
+	wm.lock()
+	defer wm.unlock()
+	return wm.snapToEdges
+}
+
+// Snap snaps the given window to the given zone of the work area.
+// The window gets back the size it had before when it is dragged away or
+// snapped to SnapNone.
+func (wm *Manager) Snap(window Window, zone SnapZone) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.snap(window, zone)
+	return wm
+}
+
+func (wm *Manager) snap(window Window, zone SnapZone) {
+	state := wm.state(window)
+	if zone == state.snap {
+		return
+	}
+	if state.snap == SnapNone {
+		state.snapRestore = NewRect(window.GetRect())
+	}
+	state.snap = zone
+	if zone == SnapNone {
+		window.SetRect(state.snapRestore.Rect())
+	} else {
+		rect := zone.Rect(wm.visibleRect(wm.workArea))
+		window.SetRect(rect.Rect())
+	}
+}
+
+// GetSnap returns the zone the given window is snapped to
+func (wm *Manager) GetSnap(window Window) SnapZone {
+	wm.lock()
+	defer wm.unlock()
+	if state, ok := wm.states[window]; ok {
+		return state.snap
+	}
+	return SnapNone
+}
+
+// drawOutline draws a frame with the given coordinates, used to preview window geometry
+func drawOutline(screen tcell.Screen, rect Rect, style tcell.Style) {
+	if rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	right, bottom := rect.X+rect.W-1, rect.Y+rect.H-1
+	for x := rect.X + 1; x < right; x++ {
+		screen.SetContent(x, rect.Y, tview.Borders.Horizontal, nil, style)
+		screen.SetContent(x, bottom, tview.Borders.Horizontal, nil, style)
+	}
+	for y := rect.Y + 1; y < bottom; y++ {
+		screen.SetContent(rect.X, y, tview.Borders.Vertical, nil, style)
+		screen.SetContent(right, y, tview.Borders.Vertical, nil, style)
+	}
+	screen.SetContent(rect.X, rect.Y, tview.Borders.TopLeft, nil, style)
+	screen.SetContent(right, rect.Y, tview.Borders.TopRight, nil, style)
+	screen.SetContent(rect.X, bottom, tview.Borders.BottomLeft, nil, style)
+	screen.SetContent(right, bottom, tview.Borders.BottomRight, nil, style)
+}
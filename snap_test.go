@@ -0,0 +1,113 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type SnapTest struct {
+	release Position        // where the dragged window is released
+	zone    winman.SnapZone // expected snap zone
+	rect    Rect            // expected window rect after releasing
+}
+
+func TestSnap(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 10)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 10)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	handler := wm.MouseHandler()
+	mouse := func(action tview.MouseAction, x, y int) {
+		handler(action, tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone), setFocus)
+		wm.Draw(screen)
+	}
+
+	initial := winman.NewRect(5, 3, 6, 4)
+	wnd := wm.NewWindow().Show().SetDraggable(true).SetResizable(true)
+	wnd.SetRect(initial.Rect())
+	wm.Draw(screen)
+
+	// snapping is disabled by default
+	if wm.GetSnapToEdges() {
+		t.Fatal("Expected snapping to be disabled by default")
+	}
+	mouse(tview.MouseLeftDown, 7, 3)
+	mouse(tview.MouseMove, 2, 0)
+	mouse(tview.MouseLeftUp, 2, 0)
+	if zone := wm.GetSnap(wnd); zone != winman.SnapNone {
+		t.Fatalf("Expected window to not snap when snapping is disabled, got %d", zone)
+	}
+	wnd.SetRect(initial.Rect())
+
+	wm.SetSnapToEdges(true)
+	snapTests := []SnapTest{
+		{Position{0, 5}, winman.SnapLeft, Rect{0, 0, 10, 10}},
+		{Position{19, 5}, winman.SnapRight, Rect{10, 0, 10, 10}},
+		{Position{8, 0}, winman.SnapTop, Rect{0, 0, 20, 10}},
+		{Position{0, 0}, winman.SnapTopLeft, Rect{0, 0, 10, 5}},
+		{Position{19, 0}, winman.SnapTopRight, Rect{10, 0, 10, 5}},
+		{Position{0, 9}, winman.SnapBottomLeft, Rect{0, 5, 10, 5}},
+		{Position{19, 9}, winman.SnapBottomRight, Rect{10, 5, 10, 5}},
+		{Position{8, 5}, winman.SnapNone, Rect{6, 5, 6, 4}},
+	}
+
+	for i, st := range snapTests {
+		// grab the window by its title bar and drag it to the test position
+		x, y, _, _ := wnd.GetRect()
+		mouse(tview.MouseLeftDown, x+2, y)
+		mouse(tview.MouseMove, st.release.x, st.release.y)
+
+		// while dragging, the window keeps its original size
+		if _, _, w, h := wnd.GetRect(); w != initial.W || h != initial.H {
+			t.Fatalf("snap test #%d: Expected dragged window to get back its size %dx%d, got %dx%d", i, initial.W, initial.H, w, h)
+		}
+		// and the snap zone is previewed
+		if st.zone != winman.SnapNone {
+			sm.Sync()
+			preview := st.zone.Rect(winman.NewRect(wm.WorkArea()))
+			if c := sm.Char(preview.X, preview.Y); c != string(tview.Borders.TopLeft) {
+				t.Fatalf("snap test #%d: Expected snap preview to be drawn at %s, got %q", i, preview, c)
+			}
+		}
+
+		mouse(tview.MouseLeftUp, st.release.x, st.release.y)
+		if zone := wm.GetSnap(wnd); zone != st.zone {
+			t.Fatalf("snap test #%d: Expected window to snap to zone %d, got %d", i, st.zone, zone)
+		}
+		if rect := winman.NewRect(wnd.GetRect()); rect != st.rect {
+			t.Fatalf("snap test #%d: Expected window rect to be %s, got %s", i, st.rect, rect)
+		}
+	}
+
+	// snapped windows follow the work area
+	wm.Snap(wnd, winman.SnapRight)
+	wm.SetRect(0, 0, 30, 10)
+	wm.Draw(screen)
+	if rect := winman.NewRect(wnd.GetRect()); rect != winman.NewRect(15, 0, 15, 10) {
+		t.Fatalf("Expected snapped window to follow the work area, got %s", rect)
+	}
+
+	// resizing a snapped window unsnaps it, keeping its size
+	mouse(tview.MouseLeftDown, 15, 5)
+	mouse(tview.MouseMove, 13, 5)
+	mouse(tview.MouseLeftUp, 13, 5)
+	if zone := wm.GetSnap(wnd); zone != winman.SnapNone {
+		t.Fatalf("Expected resized window to not be snapped, got %d", zone)
+	}
+	if rect := winman.NewRect(wnd.GetRect()); rect != winman.NewRect(13, 0, 17, 10) {
+		t.Fatalf("Expected resized window to keep its size, got %s", rect)
+	}
+
+	// unsnapping programmatically restores the former coordinates
+	wm.Snap(wnd, winman.SnapLeft).Snap(wnd, winman.SnapNone)
+	if rect := winman.NewRect(wnd.GetRect()); rect != winman.NewRect(13, 0, 17, 10) {
+		t.Fatalf("Expected unsnapped window to get back its rect, got %s", rect)
+	}
+}
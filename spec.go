@@ -0,0 +1,205 @@
+package winman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/tview"
+)
+
+// Registry maps the names used in a desktop spec to constructors of window root primitives
+type Registry map[string]func() tview.Primitive
+
+// Spec describes a whole desktop, so it can be kept in a configuration file and
+// built with Load. Specs are written in JSON and decoded with ReadSpec.
+type Spec struct {
+	Layout  string       `json:"layout,omitempty"` // floating (the default), masterstack, grid or monocle
+	Windows []WindowSpec `json:"windows"`          // windows, from the bottom to the top of the z order
+}
+
+// WindowSpec describes a window of a desktop spec
+type WindowSpec struct {
+	ID        string       `json:"id,omitempty"`        // identifier, used to find the window and to refer to it as a parent
+	Title     string       `json:"title,omitempty"`     // window title
+	Root      string       `json:"root,omitempty"`      // name of the root primitive in the registry
+	Rect      *Rect        `json:"rect,omitempty"`      // coordinates. Without them, the window is placed by the placement policy
+	Hidden    bool         `json:"hidden,omitempty"`    // windows are shown unless hidden
+	Border    *bool        `json:"border,omitempty"`    // whether the window has a border. The default is true
+	Draggable bool         `json:"draggable,omitempty"` // whether the user can move the window
+	Resizable bool         `json:"resizable,omitempty"` // whether the user can resize the window
+	Modal     bool         `json:"modal,omitempty"`     // whether the window is modal
+	Floating  bool         `json:"floating,omitempty"`  // whether the window is left out of tiling layouts
+	Maximized bool         `json:"maximized,omitempty"` // whether the window starts maximized
+	Minimized bool         `json:"minimized,omitempty"` // whether the window starts minimized
+	Layer     string       `json:"layer,omitempty"`     // desktop, normal (the default), alwaysontop, popup or notification
+	Parent    string       `json:"parent,omitempty"`    // id of the parent window
+	Buttons   []ButtonSpec `json:"buttons,omitempty"`   // title bar buttons
+}
+
+// ButtonSpec describes a title bar button of a window spec
+type ButtonSpec struct {
+	Symbol    string `json:"symbol"`              // a single character
+	Alignment string `json:"alignment,omitempty"` // left (the default) or right
+	Action    string `json:"action,omitempty"`    // close, minimize or maximize, which toggles maximized state
+}
+
+// layouts maps layout names of a spec to layouts
+var layouts = map[string]Layout{
+	"":            FloatingLayout{},
+	"floating":    FloatingLayout{},
+	"masterstack": MasterStackLayout{},
+	"grid":        GridLayout{},
+	"monocle":     MonocleLayout{},
+}
+
+// layers maps layer names of a spec to layers
+var layers = map[string]Layer{
+	"":             LayerNormal,
+	"desktop":      LayerDesktop,
+	"normal":       LayerNormal,
+	"alwaysontop":  LayerAlwaysOnTop,
+	"popup":        LayerPopup,
+	"notification": LayerNotification,
+}
+
+// ReadSpec decodes a desktop spec from JSON
+func ReadSpec(r io.Reader) (*Spec, error) {
+	var spec Spec
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("winman: cannot read spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// buttonAction returns the callback of a title bar button with the given action
+func buttonAction(window *WindowBase, action string) (func(), error) {
+	switch strings.ToLower(action) {
+	case "":
+		return nil, nil
+	case "close":
+		return func() { window.Close() }, nil
+	case "minimize":
+		return func() { window.Minimize() }, nil
+	case "maximize":
+		return func() {
+			if window.IsMaximized() {
+				window.Restore()
+			} else {
+				window.Maximize()
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown button action %q", action)
+}
+
+// newWindowFromSpec creates a window as described by the given window spec
+func newWindowFromSpec(spec WindowSpec, registry Registry) (*WindowBase, error) {
+	window := NewWindow().SetID(spec.ID).SetTitle(spec.Title)
+	if spec.Root != "" {
+		constructor := registry[spec.Root]
+		if constructor == nil {
+			return nil, fmt.Errorf("no primitive registered as %q", spec.Root)
+		}
+		window.SetRoot(constructor())
+	}
+	if spec.Rect != nil {
+		window.SetRect(spec.Rect.Rect())
+	}
+	if spec.Border != nil {
+		window.SetBorder(*spec.Border)
+	}
+	layer, ok := layers[strings.ToLower(spec.Layer)]
+	if !ok {
+		return nil, fmt.Errorf("unknown layer %q", spec.Layer)
+	}
+	window.SetDraggable(spec.Draggable).
+		SetResizable(spec.Resizable).
+		SetModal(spec.Modal).
+		SetFloating(spec.Floating).
+		SetLayer(layer)
+	for _, b := range spec.Buttons {
+		symbol, size := utf8.DecodeRuneInString(b.Symbol)
+		if size == 0 || size != len(b.Symbol) {
+			return nil, fmt.Errorf("button symbol %q must be a single character", b.Symbol)
+		}
+		button := &Button{Symbol: symbol}
+		switch strings.ToLower(b.Alignment) {
+		case "", "left":
+			button.Alignment = ButtonLeft
+		case "right":
+			button.Alignment = ButtonRight
+		default:
+			return nil, fmt.Errorf("unknown button alignment %q", b.Alignment)
+		}
+		onClick, err := buttonAction(window, b.Action)
+		if err != nil {
+			return nil, err
+		}
+		button.OnClick = onClick
+		window.AddButton(button)
+	}
+	if spec.Maximized {
+		window.Maximize()
+	}
+	if spec.Minimized {
+		window.Minimize()
+	}
+	if !spec.Hidden {
+		window.Show()
+	}
+	return window, nil
+}
+
+// Load creates a window manager with the desktop described by the given spec.
+// Window roots are created with the constructors of the registry.
+// If the spec is invalid, for example because it refers to a primitive
+// that is not in the registry, an error is returned
+func Load(spec *Spec, registry Registry) (*Manager, error) {
+	layout, ok := layouts[strings.ToLower(spec.Layout)]
+	if !ok {
+		return nil, fmt.Errorf("winman: unknown layout %q", spec.Layout)
+	}
+
+	windows := make([]*WindowBase, len(spec.Windows))
+	byID := make(map[string]*WindowBase)
+	parentIDs := make(map[string]string)
+	for i, ws := range spec.Windows {
+		window, err := newWindowFromSpec(ws, registry)
+		if err != nil {
+			return nil, fmt.Errorf("winman: invalid window #%d %q: %w", i, ws.ID, err)
+		}
+		if ws.ID != "" {
+			if byID[ws.ID] != nil {
+				return nil, fmt.Errorf("winman: duplicate window id %q", ws.ID)
+			}
+			byID[ws.ID] = window
+			parentIDs[ws.ID] = ws.Parent
+		}
+		windows[i] = window
+	}
+	for i, ws := range spec.Windows {
+		if ws.Parent == "" {
+			continue
+		}
+		parent := byID[ws.Parent]
+		if parent == nil {
+			return nil, fmt.Errorf("winman: invalid parent %q for window %q", ws.Parent, ws.ID)
+		}
+		// follow the parents up, as far as there are windows, to find cycles
+		for id, steps := ws.Parent, 0; id != ""; id, steps = parentIDs[id], steps+1 {
+			if id == ws.ID || steps >= len(spec.Windows) {
+				return nil, fmt.Errorf("winman: window %q is its own ancestor", ws.ID)
+			}
+		}
+		windows[i].SetParent(parent)
+	}
+
+	wm := NewWindowManager().SetLayout(layout)
+	for _, window := range windows {
+		wm.AddWindow(window)
+	}
+	return wm, nil
+}
@@ -0,0 +1,91 @@
+package winman_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const testSpec = `{
+	"layout": "floating",
+	"windows": [
+		{"id": "log", "title": "Log", "root": "log", "rect": {"x": 1, "y": 2, "w": 30, "h": 10}, "draggable": true,
+			"buttons": [{"symbol": "X", "alignment": "right", "action": "close"}]},
+		{"id": "monitor", "title": "Monitor", "root": "monitor", "layer": "alwaysOnTop", "border": false},
+		{"id": "about", "parent": "log", "modal": true, "hidden": true}
+	]
+}`
+
+func TestLoadSpec(t *testing.T) {
+	registry := winman.Registry{
+		"log":     func() tview.Primitive { return tview.NewTextView() },
+		"monitor": func() tview.Primitive { return NewBoringPrimitive('M') },
+	}
+	spec, err := winman.ReadSpec(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm, err := winman.Load(spec, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wm.WindowCount() != 3 {
+		t.Fatalf("Expected 3 windows, got %d", wm.WindowCount())
+	}
+	log := wm.WindowByID("log").(*winman.WindowBase)
+	if log.GetTitle() != "Log" || !log.IsVisible() || !log.Draggable || log.ButtonCount() != 1 {
+		t.Fatal("Expected the log window to be set up as described")
+	}
+	if rect := winman.NewRect(log.GetRect()); rect != (Rect{1, 2, 30, 10}) {
+		t.Fatalf("Expected the log window at its rect, got %s", rect)
+	}
+	if _, ok := log.GetRoot().(*tview.TextView); !ok {
+		t.Fatal("Expected the root of the log window to be created from the registry")
+	}
+	monitor := wm.WindowByID("monitor").(*winman.WindowBase)
+	if monitor.GetLayer() != winman.LayerAlwaysOnTop || monitor.HasBorder() {
+		t.Fatal("Expected the monitor to be always on top and borderless")
+	}
+	about := wm.WindowByID("about").(*winman.WindowBase)
+	if about.GetParent() != log || !about.IsModal() || about.IsVisible() {
+		t.Fatal("Expected the about window to be a hidden sheet of the log window")
+	}
+
+	// buttons run their action
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(80, 40)
+	screen.Init()
+	wm.SetRect(0, 0, 80, 40)
+	wm.Draw(screen)
+	log.GetButton(0).OnClick()
+	if wm.WindowCount() != 1 {
+		t.Fatalf("Expected the close button to close the log window and its sheet, %d windows left", wm.WindowCount())
+	}
+
+	// invalid specs are rejected
+	invalid := []string{
+		`{"layout": "spiral"}`,
+		`{"windows": [{"root": "unknown"}]}`,
+		`{"windows": [{"layer": "basement"}]}`,
+		`{"windows": [{"id": "a"}, {"id": "a"}]}`,
+		`{"windows": [{"id": "a", "parent": "b"}]}`,
+		`{"windows": [{"id": "a", "parent": "a"}]}`,
+		`{"windows": [{"id": "a", "parent": "b"}, {"id": "b", "parent": "a"}]}`,
+		`{"windows": [{"id": "a", "parent": "b"}, {"id": "b", "parent": "c"}, {"id": "c", "parent": "b"}]}`,
+		`{"windows": [{"buttons": [{"symbol": "XY"}]}]}`,
+		`{"windows": [{"buttons": [{"symbol": "X", "action": "explode"}]}]}`,
+	}
+	for _, s := range invalid {
+		spec, err := winman.ReadSpec(strings.NewReader(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := winman.Load(spec, registry); err == nil {
+			t.Fatalf("Expected spec %s to be rejected", s)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package winman
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SetSwitcherHotkey sets the key combination that opens the window switcher.
+// The switcher lists visible windows, most recently focused first, preselecting
+// the window focused before the current one. Pressing the hotkey again, Tab or the arrow
+// keys moves the selection. Since terminals do not report key releases, the selected window
+// is raised and focused once any other key, like Enter, is pressed. Esc closes the switcher.
+func (wm *Manager) SetSwitcherHotkey(hotkey Hotkey) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	wm.switcherHotkey = hotkey
+	return wm
+}
+
+// GetSwitcherHotkey returns the key combination that opens the window switcher
+func (wm *Manager) GetSwitcherHotkey() Hotkey {
+	wm.lock()
+	defer wm.unlock()
+	return wm.switcherHotkey
+}
+
+// FocusHistory returns the windows that have had focus, most recent first
+func (wm *Manager) FocusHistory() []Window {
+	wm.lock()
+	defer wm.unlock()
+	var windows []Window
+	for i := len(wm.history) - 1; i >= 0; i-- {
+		windows = append(windows, wm.history[i].(Window))
+	}
+	return windows
+}
+
+// IsSwitching returns true if the window switcher is open
+func (wm *Manager) IsSwitching() bool {
+	wm.lock()
+	defer wm.unlock()
+	return wm.switcherWindows != nil
+}
+
+// recordFocus moves the given window on top of the focus history
+func (wm *Manager) recordFocus(window Window) {
+	wm.history.Push(window)
+	wm.history.Move(window, WindowZTop)
+}
+
+// openSwitcher opens the window switcher with visible windows in most recently used order.
+// Windows blocked by a modal window are left out
+func (wm *Manager) openSwitcher() {
+	var windows []Window
+	for i := len(wm.history) - 1; i >= 0; i-- {
+		if window := wm.history[i].(Window); window.IsVisible() && wm.blocker(window) == nil {
+			windows = append(windows, window)
+		}
+	}
+	// windows that never had focus go last
+	for _, wndItem := range wm.order {
+		if window := wndItem.(Window); window.IsVisible() && wm.blocker(window) == nil && wm.history.IndexOf(window) == -1 {
+			windows = append(windows, window)
+		}
+	}
+	if len(windows) == 0 {
+		return
+	}
+	wm.switcherWindows = windows
+	wm.switcherIndex = 1 % len(windows)
+}
+
+// switcherKey handles a key press while the window switcher is open.
+// Returns the window chosen by the user, if any
+func (wm *Manager) switcherKey(event *tcell.EventKey) Window {
+	count := len(wm.switcherWindows)
+	switch {
+	case wm.switcherHotkey.Matches(event), event.Key() == tcell.KeyTab, event.Key() == tcell.KeyDown:
+		wm.switcherIndex = (wm.switcherIndex + 1) % count
+		return nil
+	case event.Key() == tcell.KeyBacktab, event.Key() == tcell.KeyUp:
+		wm.switcherIndex = (wm.switcherIndex + count - 1) % count
+		return nil
+	case event.Key() == tcell.KeyEscape:
+		wm.switcherWindows = nil
+		return nil
+	}
+	window := wm.switcherWindows[wm.switcherIndex]
+	wm.switcherWindows = nil
+	wm.setZ(window, WindowZTop)
+	return window
+}
+
+// drawSwitcher draws the window switcher overlay centered in the work area
+func (wm *Manager) drawSwitcher(screen tcell.Screen) {
+	if wm.switcherWindows == nil {
+		return
+	}
+	width := 0
+	titles := make([]string, len(wm.switcherWindows))
+	for i, window := range wm.switcherWindows {
+		titles[i] = windowTitle(window, wm.order.IndexOf(window))
+		if w := tview.TaggedStringWidth(tview.Escape(titles[i])); w > width {
+			width = w
+		}
+	}
+	area := wm.workArea
+	rect := NewRect(0, 0, width+4, len(titles)+2)
+	if rect.W > area.W {
+		rect.W = area.W
+	}
+	if rect.H > area.H {
+		rect.H = area.H
+	}
+	rect.X = area.X + (area.W-rect.W)/2
+	rect.Y = area.Y + (area.H-rect.H)/2
+
+	background := tcell.StyleDefault.Background(tview.Styles.PrimitiveBackgroundColor)
+	highlight := tcell.StyleDefault.Background(tview.Styles.ContrastBackgroundColor)
+	for y := rect.Y; y < rect.Y+rect.H; y++ {
+		style := background
+		if y-rect.Y-1 == wm.switcherIndex {
+			style = highlight
+		}
+		for x := rect.X; x < rect.X+rect.W; x++ {
+			screen.SetContent(x, y, ' ', nil, style)
+		}
+	}
+	drawOutline(screen, rect, background.Foreground(tview.Styles.BorderColor))
+	for i, title := range titles {
+		if i >= rect.H-2 {
+			break
+		}
+		tview.Print(screen, tview.Escape(title), rect.X+2, rect.Y+1+i, rect.W-4, tview.AlignLeft, tview.Styles.PrimaryTextColor)
+	}
+}
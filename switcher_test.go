@@ -0,0 +1,119 @@
+package winman_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestSwitcher(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+	inputHandler := wm.InputHandler()
+	press := func(key tcell.Key) {
+		inputHandler(tcell.NewEventKey(key, 0, tcell.ModNone), setFocus)
+		wm.Draw(screen)
+	}
+
+	var windows []*winman.WindowBase
+	for _, title := range []string{"A", "B", "C"} {
+		wnd := wm.NewWindow().Show().SetTitle(title).SetRoot(&KeyTestPrimitive{})
+		wnd.SetRect(0, 0, 5, 5)
+		windows = append(windows, wnd)
+	}
+	wndA, wndB, wndC := windows[0], windows[1], windows[2]
+	wm.NewWindow().SetTitle("Hidden") // hidden windows are not listed
+
+	// focus each window in turn to build up the focus history
+	for _, wnd := range []*winman.WindowBase{wndA, wndC, wndB} {
+		setFocus(wnd)
+		wm.Draw(screen)
+	}
+	checkHistory := func(expected ...winman.Window) {
+		history := wm.FocusHistory()
+		if len(history) != len(expected) {
+			t.Fatalf("Expected focus history to have %d windows, got %d", len(expected), len(history))
+		}
+		for i := range expected {
+			if history[i] != expected[i] {
+				t.Fatalf("Expected window #%d in focus history to be %v, got %v", i, expected[i], history[i])
+			}
+		}
+	}
+	checkHistory(wndB, wndC, wndA)
+
+	// without a hotkey, the switcher does not open
+	press(tcell.KeyF2)
+	if wm.IsSwitching() {
+		t.Fatal("Expected the switcher to not open without a hotkey")
+	}
+
+	wm.SetSwitcherHotkey(winman.Hotkey{Key: tcell.KeyF2})
+	lastPrimitive = nil
+	press(tcell.KeyF2)
+	if !wm.IsSwitching() {
+		t.Fatal("Expected the switcher to open")
+	}
+
+	// the overlay lists windows most recent first,
+	// with the previously focused window selected
+	sm.Sync()
+	var listed []string
+	for y := 0; y < 20; y++ {
+		line := strings.Trim(sm.Line(0, y, 20), " │")
+		if len(line) == 1 {
+			listed = append(listed, line)
+		}
+	}
+	if strings.Join(listed, "") != "BCA" {
+		t.Fatalf("Expected switcher to list windows as BCA, got %v", listed)
+	}
+	_, _, style, _ := screen.GetContent(10, 9)
+	if _, bg, _ := style.Decompose(); bg != tview.Styles.ContrastBackgroundColor {
+		t.Fatal("Expected the second entry to be selected")
+	}
+
+	// cycle to window A, then back to C, and confirm
+	press(tcell.KeyF2)
+	press(tcell.KeyUp)
+	press(tcell.KeyEnter)
+	if lastPrimitive != nil {
+		t.Fatal("Expected keys to not reach windows while switching")
+	}
+	if wm.IsSwitching() {
+		t.Fatal("Expected the switcher to close after confirming")
+	}
+	if !wndC.HasFocus() || wm.GetZ(wndC) != wm.WindowCount()-1 {
+		t.Fatal("Expected window C to be focused and raised")
+	}
+	checkHistory(wndC, wndB, wndA)
+
+	// Esc closes the switcher without changing focus
+	press(tcell.KeyF2)
+	press(tcell.KeyEscape)
+	if wm.IsSwitching() || !wndC.HasFocus() {
+		t.Fatal("Expected Esc to close the switcher without changing focus")
+	}
+
+	// minimized windows are listed, and restored when chosen
+	wndB.Minimize()
+	press(tcell.KeyF2)
+	press(tcell.KeyEnter)
+	if wndB.IsMinimized() || !wndB.HasFocus() {
+		t.Fatal("Expected minimized window B to be restored and focused")
+	}
+
+	// removed windows are forgotten
+	wm.RemoveWindow(wndA)
+	checkHistory(wndB, wndC)
+}
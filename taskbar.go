@@ -0,0 +1,145 @@
+package winman
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DefaultTaskbarEntryWidth is the maximum width of a taskbar entry,
+// unless changed with SetEntryWidth
+const DefaultTaskbarEntryWidth = 20
+
+// Taskbar is a primitive that shows one entry for each visible window
+// of a window manager that has no parent, in the order windows were added.
+// The entry of the focused window is highlighted and entries of minimized windows are dimmed.
+// Clicking an entry focuses the window, raising or restoring it.
+type Taskbar struct {
+	*tview.Box
+	manager    *Manager       // window manager whose windows are listed
+	entryWidth int            // maximum width of each entry
+	entries    []taskbarEntry // entries as laid out in the last Draw
+}
+
+// taskbarEntry remembers where a window entry was drawn
+type taskbarEntry struct {
+	window Window
+	x      int
+	width  int
+}
+
+// NewTaskbar creates a new taskbar bound to the given window manager
+func NewTaskbar(manager *Manager) *Taskbar {
+	return &Taskbar{
+		Box:        tview.NewBox(),
+		manager:    manager,
+		entryWidth: DefaultTaskbarEntryWidth,
+	}
+}
+
+// SetEntryWidth sets the maximum width of each taskbar entry
+func (tb *Taskbar) SetEntryWidth(width int) *Taskbar {
+	tb.entryWidth = width
+	return tb
+}
+
+// GetEntryWidth returns the maximum width of each taskbar entry
+func (tb *Taskbar) GetEntryWidth() int {
+	return tb.entryWidth
+}
+
+// windowTitle returns the title of the given window, or a generic title
+// if the window does not have one
+func windowTitle(window Window, i int) string {
+	if t, ok := window.(interface{ GetTitle() string }); ok && t.GetTitle() != "" {
+		return t.GetTitle()
+	}
+	return fmt.Sprintf("Window %d", i+1)
+}
+
+// Draw draws this primitive onto the screen.
+// implements tview.Primitive.Draw
+func (tb *Taskbar) Draw(screen tcell.Screen) {
+	tb.Box.DrawForSubclass(screen, tb)
+	x, y, width, height := tb.GetInnerRect()
+	tb.entries = tb.entries[:0]
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	tb.manager.Lock()
+	var windows []Window
+	for _, wndItem := range tb.manager.order {
+		window := wndItem.(Window)
+		if window.IsVisible() && getParent(window) == nil {
+			windows = append(windows, window)
+		}
+	}
+	tb.manager.Unlock()
+
+	if len(windows) == 0 {
+		return
+	}
+
+	// shrink entries if they don't fit. Each entry is followed by a space
+	entryWidth := tb.entryWidth
+	if len(windows)*(entryWidth+1) > width {
+		entryWidth = width/len(windows) - 1
+	}
+	if entryWidth < 1 {
+		entryWidth = 1
+	}
+
+	right := x + width
+	for i, window := range windows {
+		if x+entryWidth > right {
+			break
+		}
+		color := tview.Styles.PrimaryTextColor
+		attributes := ""
+		switch {
+		case window.IsMinimized():
+			color = tview.Styles.SecondaryTextColor
+			attributes = "[::d]"
+		case window.HasFocus():
+			attributes = "[::b]"
+			background := tcell.StyleDefault.Background(tview.Styles.ContrastBackgroundColor)
+			for bx := x; bx < x+entryWidth; bx++ {
+				screen.SetContent(bx, y, ' ', nil, background)
+			}
+		}
+		tview.Print(screen, attributes+tview.Escape(" "+windowTitle(window, i)), x, y, entryWidth, tview.AlignLeft, color)
+		tb.entries = append(tb.entries, taskbarEntry{window: window, x: x, width: entryWidth})
+		x += entryWidth + 1
+	}
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+// implements tview.Primitive.MouseHandler
+func (tb *Taskbar) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return tb.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		x, y := event.Position()
+		if !tb.InRect(x, y) {
+			return false, nil
+		}
+		if action != tview.MouseLeftClick {
+			return true, nil
+		}
+		for _, entry := range tb.entries {
+			if x >= entry.x && x < entry.x+entry.width {
+				// raise the window and give it focus.
+				// Focusing a minimized window restores it.
+				// Windows blocked by a modal window stay below it.
+				tb.manager.lock()
+				if tb.manager.blocker(entry.window) == nil {
+					tb.manager.setZ(entry.window, WindowZTop)
+				}
+				tb.manager.unlock()
+				setFocus(entry.window)
+				break
+			}
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,91 @@
+package winman_test
+
+import (
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestTaskbar(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 19)
+	taskbar := winman.NewTaskbar(wm).SetEntryWidth(6)
+	taskbar.SetRect(0, 19, 40, 1)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	sm := &ScreenMonitor{screen: screen}
+
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	wndA := wm.NewWindow().SetRoot(NewBoringPrimitive('A')).SetTitle("A").Show()
+	wndA.SetRect(0, 0, 10, 10)
+	wndB := wm.NewWindow().SetRoot(NewBoringPrimitive('B')).SetTitle("B").Show()
+	wndB.SetRect(5, 5, 10, 10)
+	wm.NewWindow().SetTitle("Hidden") // hidden windows are not listed
+	wndD := wm.NewWindow().SetRoot(NewBoringPrimitive('D')).Show()
+	wndD.SetRect(10, 5, 10, 10)
+
+	wndB.Minimize()
+	setFocus(wndA)
+	wm.Draw(screen)
+	taskbar.Draw(screen)
+	sm.Sync()
+
+	expected := " A      B      Windo"
+	if line := sm.Line(0, 19, len(expected)); line != expected {
+		t.Fatalf("Expected taskbar to read %q, got %q", expected, line)
+	}
+
+	// the focused window entry must be highlighted,
+	// and the minimized window entry must be dimmed
+	_, _, style, _ := screen.GetContent(1, 19)
+	_, bg, attr := style.Decompose()
+	if bg != tview.Styles.ContrastBackgroundColor || attr&tcell.AttrBold == 0 {
+		t.Fatal("Expected the focused window entry to be highlighted")
+	}
+	_, _, style, _ = screen.GetContent(8, 19)
+	if _, _, attr = style.Decompose(); attr&tcell.AttrDim == 0 {
+		t.Fatal("Expected the minimized window entry to be dimmed")
+	}
+
+	// clicking the minimized window's entry restores, raises and focuses it
+	taskbar.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(9, 19, tcell.Button1, tcell.ModNone), setFocus)
+	if wndB.IsMinimized() {
+		t.Fatal("Expected clicking on the entry to restore the window")
+	}
+	if !wndB.HasFocus() {
+		t.Fatal("Expected clicking on the entry to focus the window")
+	}
+	if z := wm.GetZ(wndB); z != wm.WindowCount()-1 {
+		t.Fatalf("Expected clicking on the entry to raise the window, got z=%d", z)
+	}
+
+	// entries keep their order even though z indices changed
+	wm.Draw(screen)
+	taskbar.Draw(screen)
+	sm.Sync()
+	if line := sm.Line(0, 19, len(expected)); line != expected {
+		t.Fatalf("Expected taskbar to read %q, got %q", expected, line)
+	}
+
+	// clicking on the taskbar outside entries does nothing, but the event is consumed
+	consumed, _ := taskbar.MouseHandler()(tview.MouseLeftClick, tcell.NewEventMouse(39, 19, tcell.Button1, tcell.ModNone), setFocus)
+	if !consumed || !wndB.HasFocus() {
+		t.Fatal("Expected click outside entries to be consumed without changing focus")
+	}
+
+	// entries shrink when they don't fit
+	taskbar.SetRect(0, 19, 9, 1)
+	screen.Clear()
+	taskbar.Draw(screen)
+	sm.Sync()
+	expected = " A  B  W"
+	if line := sm.Line(0, 19, len(expected)); line != expected {
+		t.Fatalf("Expected taskbar to read %q, got %q", expected, line)
+	}
+}
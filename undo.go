@@ -0,0 +1,81 @@
+package winman
+
+// maxUndoSteps limits how many geometry changes the window manager remembers
+const maxUndoSteps = 100
+
+// geometry is the place of a window before it was changed
+type geometry struct {
+	window Window
+	rect   Rect
+	snap   SnapZone
+}
+
+// geometryOf returns the current place of the given window
+func (wm *Manager) geometryOf(window Window) geometry {
+	return geometry{window: window, rect: NewRect(window.GetRect()), snap: wm.state(window).snap}
+}
+
+// changed returns true if the window is no longer where it was
+func (wm *Manager) changed(g geometry) bool {
+	return wm.geometryOf(g.window) != g
+}
+
+// pushUndo remembers the given places of windows as a single step that
+// can be undone, ignoring windows that did not change
+func (wm *Manager) pushUndo(step []geometry) {
+	var changed []geometry
+	for _, g := range step {
+		if wm.changed(g) {
+			changed = append(changed, g)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	wm.undoSteps = append(wm.undoSteps, changed)
+	if len(wm.undoSteps) > maxUndoSteps {
+		wm.undoSteps = wm.undoSteps[len(wm.undoSteps)-maxUndoSteps:]
+	}
+}
+
+// forgetUndo removes the given window from the undo history
+func (wm *Manager) forgetUndo(window Window) {
+	steps := wm.undoSteps[:0]
+	for _, step := range wm.undoSteps {
+		kept := step[:0]
+		for _, g := range step {
+			if g.window != window {
+				kept = append(kept, g)
+			}
+		}
+		if len(kept) > 0 {
+			steps = append(steps, kept)
+		}
+	}
+	wm.undoSteps = steps
+}
+
+// Undo brings windows back to where they were before the last move or resize done
+// by the user, or before the last arrangement command such as Cascade or TileGrid.
+// Returns false if there is nothing to undo
+func (wm *Manager) Undo() bool {
+	wm.lock()
+	defer wm.unlock()
+	if len(wm.undoSteps) == 0 {
+		return false
+	}
+	step := wm.undoSteps[len(wm.undoSteps)-1]
+	wm.undoSteps = wm.undoSteps[:len(wm.undoSteps)-1]
+	for _, g := range step {
+		wm.state(g.window).snap = g.snap
+		g.window.SetRect(g.rect.Rect())
+	}
+	return true
+}
+
+// CanUndo returns true if there are window moves or resizes that can be undone
+func (wm *Manager) CanUndo() bool {
+	wm.lock()
+	defer wm.unlock()
+	return len(wm.undoSteps) > 0
+}
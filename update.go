@@ -0,0 +1,44 @@
+package winman
+
+// SetUpdateFunc sets the function QueueUpdate uses to run updates in the event loop
+// of the application, usually one calling tview.Application.QueueUpdateDraw
+func (wm *Manager) SetUpdateFunc(update func(f func())) *Manager {
+	wm.updateMutex.Lock()
+	defer wm.updateMutex.Unlock()
+	wm.updateFunc = update
+	return wm
+}
+
+// GetUpdateFunc returns the function set with SetUpdateFunc, or nil if there is none
+func (wm *Manager) GetUpdateFunc() func(f func()) {
+	wm.updateMutex.Lock()
+	defer wm.updateMutex.Unlock()
+	return wm.updateFunc
+}
+
+// QueueUpdate runs the given function in the event loop of the application, so it can
+// safely change windows and primitives. It can be called from any goroutine.
+// Updates go through the function set with SetUpdateFunc. Without one, they are
+// queued and run at the beginning of the next Draw
+func (wm *Manager) QueueUpdate(f func()) {
+	wm.updateMutex.Lock()
+	update := wm.updateFunc
+	if update == nil {
+		wm.pendingUpdates = append(wm.pendingUpdates, f)
+	}
+	wm.updateMutex.Unlock()
+	if update != nil {
+		update(f)
+	}
+}
+
+// runUpdates runs the updates queued since the last Draw
+func (wm *Manager) runUpdates() {
+	wm.updateMutex.Lock()
+	updates := wm.pendingUpdates
+	wm.pendingUpdates = nil
+	wm.updateMutex.Unlock()
+	for _, f := range updates {
+		f()
+	}
+}
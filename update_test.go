@@ -0,0 +1,53 @@
+package winman_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestQueueUpdate(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 20, 10)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 10)
+	screen.Init()
+	wnd := wm.NewWindow().Show()
+
+	// without an update function, updates run on the next Draw
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wm.QueueUpdate(func() {
+				wnd.SetTitle(wnd.GetTitle() + "x")
+			})
+		}()
+	}
+	wg.Wait()
+	if wnd.GetTitle() != "" {
+		t.Fatal("Expected updates to wait for the next Draw")
+	}
+	wm.Draw(screen)
+	if wnd.GetTitle() != "xxxxxxxxxx" {
+		t.Fatalf("Expected all updates to run on Draw, got %q", wnd.GetTitle())
+	}
+
+	// with an update function, updates go through it
+	var queued []func()
+	wm.SetUpdateFunc(func(f func()) {
+		queued = append(queued, f)
+	})
+	wm.QueueUpdate(func() { wnd.SetTitle("updated") })
+	wm.Draw(screen)
+	if len(queued) != 1 || wnd.GetTitle() != "xxxxxxxxxx" {
+		t.Fatal("Expected the update to be handed to the update function")
+	}
+	queued[0]()
+	if wnd.GetTitle() != "updated" {
+		t.Fatal("Expected the update to run")
+	}
+}
@@ -27,6 +27,10 @@ type Window interface {
 	// IsVisible returns true when the window has to be drawn and can receive focus
 	IsVisible() bool
 
+	// IsMinimized returns true when the window is minimized. A minimized window
+	// keeps its coordinates and z index, but it is not drawn and cannot receive focus
+	IsMinimized() bool
+
 	// HasBorder returns true if the window must have a border
 	HasBorder() bool
 }
@@ -34,15 +38,45 @@ type Window interface {
 // WindowBase defines a basic window
 type WindowBase struct {
 	*tview.Box
+	id          string          // identifies the window, for example when saving the layout
+	typeName    string          // kind of window, used to recreate it when loading a layout
 	root        tview.Primitive // The item contained in the window
 	buttons     []*Button       // window buttons on the title bar
 	border      bool            // whether to render a border
 	restoreRect Rect            // store previous coordinates after restoring from maximize
 	maximized   bool            // whether the window is maximized to the entire window manager area
+	minimized   bool            // whether the window is minimized
 	Draggable   bool            //whether this window can be dragged around with the mouse
 	Resizable   bool            // whether this window is user-resizable
 	Modal       bool            // whether this window is modal
+	Floating    bool            // whether this window floats above tiled windows
 	Visible     bool            // whether this window is rendered
+
+	constraints SizeConstraints // limits to the size of the window
+	anchor      Anchor          // how the window keeps its place when the work area changes
+	parent      Window          // window this window belongs to, if any
+	layer       Layer           // layer of the window in the z order
+
+	explicitPosition bool // whether the position of the window was set, so it does not need to be placed
+
+	manager      *Manager                 // window manager this window belongs to
+	self         Window                   // window as added to the manager, which may embed this WindowBase
+	eventHandler func(event *WindowEvent) // function called on window events
+}
+
+// windowBase returns this WindowBase, so the window manager can reach it
+// through types that embed it
+func (w *WindowBase) windowBase() *WindowBase {
+	return w
+}
+
+// getBase returns the WindowBase of the given window, or nil
+// if the window is not based on WindowBase
+func getBase(window Window) *WindowBase {
+	if b, ok := window.(interface{ windowBase() *WindowBase }); ok {
+		return b.windowBase()
+	}
+	return nil
 }
 
 // NewWindow creates a new window
@@ -55,6 +89,113 @@ func NewWindow() *WindowBase {
 	return window
 }
 
+// window returns this window as it was added to the window manager
+func (w *WindowBase) window() Window {
+	if w.self != nil {
+		return w.self
+	}
+	return w
+}
+
+// emit raises an event of the given type for this window
+func (w *WindowBase) emit(eventType WindowEventType, oldRect Rect) {
+	if w.manager == nil && w.eventHandler == nil {
+		return
+	}
+	event := &WindowEvent{Type: eventType, Window: w.window(), OldRect: oldRect}
+	if w.manager != nil {
+		w.manager.dispatch(event)
+	} else {
+		w.eventHandler(event)
+	}
+}
+
+// SetEventHandler sets a function that is called whenever the window is shown, hidden,
+// focused, blurred, moved, resized, maximized, minimized, restored, changes its z index
+// or is about to close. Close events can be cancelled. See WindowEvent
+func (w *WindowBase) SetEventHandler(handler func(event *WindowEvent)) *WindowBase {
+	w.eventHandler = handler
+	return w
+}
+
+// SetRect sets a new position and size for this window. A window positioned this way
+// is not placed by the placement policy of the window manager. See SetSize
+func (w *WindowBase) SetRect(x, y, width, height int) {
+	oldRect := NewRect(w.GetRect())
+	w.explicitPosition = true
+	w.Box.SetRect(x, y, width, height)
+	if oldRect.X != x || oldRect.Y != y {
+		w.emit(WindowMove, oldRect)
+	}
+	if oldRect.W != width || oldRect.H != height {
+		w.emit(WindowResize, oldRect)
+	}
+}
+
+// SetSize sets a new size for this window, keeping its position. Unlike SetRect,
+// it leaves a window that was not positioned yet to the placement policy
+func (w *WindowBase) SetSize(width, height int) *WindowBase {
+	x, y, _, _ := w.GetRect()
+	positioned := w.explicitPosition
+	w.SetRect(x, y, width, height)
+	w.explicitPosition = positioned
+	return w
+}
+
+// Close closes the window, hiding it and removing it from its window manager.
+// Windows whose parent is this window are closed first.
+// Event handlers can cancel the WindowClose event of this window or of its children
+// to keep the window open, in which case Close returns false
+func (w *WindowBase) Close() bool {
+	event := &WindowEvent{Type: WindowClose, Window: w.window()}
+	if w.manager != nil {
+		w.manager.deliver(event)
+	} else if w.eventHandler != nil {
+		w.eventHandler(event)
+	}
+	if event.IsCancelled() {
+		return false
+	}
+	// windows that belong to this one are closed along with it
+	if w.manager != nil {
+		for _, child := range w.manager.Children(w.window()) {
+			if c, ok := child.(interface{ Close() bool }); ok && !c.Close() {
+				return false
+			}
+		}
+	}
+	w.Hide()
+	if w.manager != nil {
+		w.manager.RemoveWindow(w.window())
+	}
+	return true
+}
+
+// SetID sets the identifier of this window, used to find it with Manager.WindowByID
+// and to save and load the desktop layout
+func (w *WindowBase) SetID(id string) *WindowBase {
+	w.id = id
+	return w
+}
+
+// GetID returns the identifier of this window
+func (w *WindowBase) GetID() string {
+	return w.id
+}
+
+// SetTypeName sets the kind of this window. When loading a layout, windows that do not exist
+// yet are created with the factory registered for their type name.
+// See Manager.RegisterWindowType
+func (w *WindowBase) SetTypeName(typeName string) *WindowBase {
+	w.typeName = typeName
+	return w
+}
+
+// GetTypeName returns the kind of this window
+func (w *WindowBase) GetTypeName() string {
+	return w.typeName
+}
+
 // SetRoot sets the main content of the window
 func (w *WindowBase) SetRoot(root tview.Primitive) *WindowBase {
 	w.root = root
@@ -66,7 +207,9 @@ func (w *WindowBase) GetRoot() tview.Primitive {
 	return w.root
 }
 
-// SetModal makes this window modal. A modal window captures all input
+// SetModal makes this window modal. A modal window captures all input:
+// mouse, keys and focus requests for the windows below it go to the modal window instead.
+// A modal window with a parent only blocks its parent
 func (w *WindowBase) SetModal(modal bool) *WindowBase {
 	w.Modal = modal
 	return w
@@ -91,6 +234,18 @@ func (w *WindowBase) SetBorder(show bool) *WindowBase {
 	return w
 }
 
+// IsFloating returns true if this window is never tiled by the window manager layout
+func (w *WindowBase) IsFloating() bool {
+	return w.Floating
+}
+
+// SetFloating sets if this window must float above tiled windows, being excluded
+// from the window manager layout
+func (w *WindowBase) SetFloating(floating bool) *WindowBase {
+	w.Floating = floating
+	return w
+}
+
 // IsDraggable returns true if this window can be dragged by the user
 func (w *WindowBase) IsDraggable() bool {
 	return w.Draggable
@@ -113,6 +268,67 @@ func (w *WindowBase) SetResizable(resizable bool) *WindowBase {
 	return w
 }
 
+// SetSizeConstraints sets the minimum, maximum, aspect ratio and step size
+// this window must respect when it is resized or arranged by the window manager
+func (w *WindowBase) SetSizeConstraints(constraints SizeConstraints) *WindowBase {
+	w.constraints = constraints
+	return w
+}
+
+// GetSizeConstraints returns the size constraints of this window
+func (w *WindowBase) GetSizeConstraints() SizeConstraints {
+	return w.constraints
+}
+
+// SetAnchor sets how the window keeps its place when the work area of
+// the window manager changes size, for example when the terminal is resized
+func (w *WindowBase) SetAnchor(anchor Anchor) *WindowBase {
+	w.anchor = anchor
+	return w
+}
+
+// GetAnchor returns how the window keeps its place when the work area changes size
+func (w *WindowBase) GetAnchor() Anchor {
+	return w.anchor
+}
+
+// SetParent sets the window this window belongs to, for example the document window
+// a dialog was opened from. Pass nil to clear it.
+func (w *WindowBase) SetParent(parent Window) *WindowBase {
+	w.parent = parent
+	return w
+}
+
+// SetLayer sets the layer of the window in the z order. Windows are kept above
+// the windows of lower layers and below the windows of higher layers
+func (w *WindowBase) SetLayer(layer Layer) *WindowBase {
+	w.layer = layer
+	if w.manager != nil {
+		w.manager.lock()
+		w.manager.sortLayers()
+		w.manager.unlock()
+	}
+	return w
+}
+
+// GetLayer returns the layer of the window in the z order
+func (w *WindowBase) GetLayer() Layer {
+	return w.layer
+}
+
+// GetParent returns the window this window belongs to, or nil if it has no parent
+func (w *WindowBase) GetParent() Window {
+	return w.parent
+}
+
+// getParent returns the parent of the given window, or nil if it has none
+func getParent(window Window) Window {
+	if base := getBase(window); base != nil {
+		return base.parent
+	}
+	return nil
+}
+
 // SetTitle sets the window title
 func (w *WindowBase) SetTitle(text string) *WindowBase {
 	w.Box.SetTitle(text)
@@ -127,13 +343,19 @@ func (w *WindowBase) IsVisible() bool {
 
 // Show makes the window visible
 func (w *WindowBase) Show() *WindowBase {
-	w.Visible = true
+	if !w.Visible {
+		w.Visible = true
+		w.emit(WindowShow, Rect{})
+	}
 	return w
 }
 
 // Hide hides this window
 func (w *WindowBase) Hide() *WindowBase {
-	w.Visible = false
+	if w.Visible {
+		w.Visible = false
+		w.emit(WindowHide, Rect{})
+	}
 	return w
 }
 
@@ -174,8 +396,11 @@ func (w *WindowBase) Draw(screen tcell.Screen) {
 
 // Maximize signals the window manager to resize this window to the maximum size available
 func (w *WindowBase) Maximize() *WindowBase {
-	w.restoreRect = NewRect(w.GetRect())
-	w.maximized = true
+	if !w.maximized {
+		w.restoreRect = NewRect(w.GetRect())
+		w.maximized = true
+		w.emit(WindowMaximize, Rect{})
+	}
 	return w
 }
 
@@ -184,26 +409,61 @@ func (w *WindowBase) IsMaximized() bool {
 	return w.maximized
 }
 
-// Restore restores the window to the size it had before maximizing
+// Minimize signals the window manager to stop drawing this window
+// until it is restored or receives focus again
+func (w *WindowBase) Minimize() *WindowBase {
+	if !w.minimized {
+		w.minimized = true
+		w.emit(WindowMinimize, Rect{})
+	}
+	return w
+}
+
+// IsMinimized returns true if this window is minimized
+func (w *WindowBase) IsMinimized() bool {
+	return w.minimized
+}
+
+// Restore restores a minimized window to the state it had before minimizing.
+// Otherwise, it restores the window to the size it had before maximizing
 func (w *WindowBase) Restore() *WindowBase {
+	if w.minimized {
+		w.minimized = false
+		w.emit(WindowRestore, Rect{})
+		return w
+	}
 	w.SetRect(w.restoreRect.Rect())
-	w.maximized = false
+	if w.maximized {
+		w.maximized = false
+		w.emit(WindowRestore, Rect{})
+	}
 	return w
 }
 
 // Focus is called when this primitive receives focus.
+// Focusing a minimized window restores it
 func (w *WindowBase) Focus(delegate func(p tview.Primitive)) {
+	// focus goes to the modal window blocking this one, if any
+	if w.manager != nil {
+		if target := w.manager.focusTarget(w.window()); target != w.window() {
+			target.Focus(delegate)
+			return
+		}
+	}
 	if w.root != nil {
 		delegate(w.root)
 	} else {
 		delegate(w.Box)
 	}
-	w.Visible = true
+	w.Show()
+	if w.minimized {
+		w.Restore()
+	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
 func (w *WindowBase) HasFocus() bool {
-	if !w.Visible {
+	if !w.Visible || w.minimized {
 		return false
 	}
 	if w.root != nil {
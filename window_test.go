@@ -291,6 +291,28 @@ func TestWindowSettings(t *testing.T) {
 		t.Fatal("Expected window to be draggable after setting draggable to true")
 	}
 
+	if wnd.IsMinimized() {
+		t.Fatal("Expected window to not be minimized by default")
+	}
+
+	wnd.Maximize().Minimize()
+
+	if !wnd.IsMinimized() || !wnd.IsMaximized() {
+		t.Fatal("Expected window to be minimized and keep its maximized state")
+	}
+
+	wnd.Restore()
+
+	if wnd.IsMinimized() || !wnd.IsMaximized() {
+		t.Fatal("Expected restoring a minimized window to bring it back maximized")
+	}
+
+	wnd.Restore()
+
+	if wnd.IsMaximized() {
+		t.Fatal("Expected window to not be maximized after restoring again")
+	}
+
 	if wnd.GetTitle() != "" {
 		t.Fatal("Expected window to not have a title by default")
 	}
@@ -0,0 +1,228 @@
+package winman
+
+// DefaultWorkspace is the name of the workspace every window manager starts with
+const DefaultWorkspace = "default"
+
+// workspace is a set of windows that are shown together
+type workspace struct {
+	name    string
+	windows Stack // windows in z order
+	order   Stack // windows in the order they were added
+	history Stack // windows that had focus, the most recent on top
+}
+
+// initWorkspaces creates the default workspace on first use
+func (wm *Manager) initWorkspaces() {
+	if wm.workspaces == nil {
+		wm.workspaces = []*workspace{{name: DefaultWorkspace}}
+	}
+}
+
+// findWorkspace returns the index of the workspace with the given name, or -1 if there is none
+func (wm *Manager) findWorkspace(name string) int {
+	wm.initWorkspaces()
+	for i, ws := range wm.workspaces {
+		if ws.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// workspaceOf returns the index of the workspace that contains the given window, or -1.
+// Windows of the active workspace live in the window manager stacks,
+// the workspace only keeps them while it is not active
+func (wm *Manager) workspaceOf(window Window) int {
+	wm.initWorkspaces()
+	if wm.windows.IndexOf(window) != -1 {
+		return wm.activeWorkspace
+	}
+	for i, ws := range wm.workspaces {
+		if i != wm.activeWorkspace && ws.windows.IndexOf(window) != -1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddWorkspace adds an empty workspace with the given name, if it does not exist yet
+func (wm *Manager) AddWorkspace(name string) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	if wm.findWorkspace(name) == -1 {
+		wm.workspaces = append(wm.workspaces, &workspace{name: name})
+	}
+	return wm
+}
+
+// RemoveWorkspace removes the workspace with the given name, moving its windows
+// to the active workspace. The active workspace cannot be removed.
+func (wm *Manager) RemoveWorkspace(name string) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	i := wm.findWorkspace(name)
+	if i == -1 || i == wm.activeWorkspace {
+		return wm
+	}
+	ws := wm.workspaces[i]
+	for _, wndItem := range ws.order {
+		wm.order.Push(wndItem)
+	}
+	for _, wndItem := range ws.windows {
+		wm.windows.Push(wndItem)
+	}
+	wm.sortLayers()
+	wm.workspaces = append(wm.workspaces[:i], wm.workspaces[i+1:]...)
+	if i < wm.activeWorkspace {
+		wm.activeWorkspace--
+	}
+	return wm
+}
+
+// Workspaces returns the names of all workspaces, in the order they were added
+func (wm *Manager) Workspaces() []string {
+	wm.lock()
+	defer wm.unlock()
+	wm.initWorkspaces()
+	names := make([]string, len(wm.workspaces))
+	for i, ws := range wm.workspaces {
+		names[i] = ws.name
+	}
+	return names
+}
+
+// GetWorkspace returns the name of the active workspace
+func (wm *Manager) GetWorkspace() string {
+	wm.lock()
+	defer wm.unlock()
+	wm.initWorkspaces()
+	return wm.workspaces[wm.activeWorkspace].name
+}
+
+// SwitchWorkspace makes the workspace with the given name the active one, showing
+// its windows in the z order they had and hiding the windows of the previous workspace,
+// except sticky windows that are shown on every workspace.
+// Each workspace remembers which of its windows had focus: once switched, give
+// the focus to the window manager to focus that window again.
+// Unknown workspace names are ignored
+func (wm *Manager) SwitchWorkspace(name string) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	i := wm.findWorkspace(name)
+	if i == -1 || i == wm.activeWorkspace {
+		return wm
+	}
+
+	// sticky windows go along to the new workspace
+	var sticky []Window
+	for _, wndItem := range wm.windows {
+		if window := wndItem.(Window); wm.state(window).sticky {
+			sticky = append(sticky, window)
+		}
+	}
+	for _, window := range sticky {
+		wm.windows.Remove(window)
+		wm.order.Remove(window)
+		wm.history.Remove(window)
+	}
+
+	old := wm.workspaces[wm.activeWorkspace]
+	old.windows, old.order, old.history = wm.windows, wm.order, wm.history
+	ws := wm.workspaces[i]
+	wm.windows, wm.order, wm.history = ws.windows, ws.order, ws.history
+	ws.windows, ws.order, ws.history = nil, nil, nil
+	wm.activeWorkspace = i
+
+	for _, window := range sticky {
+		wm.windows.Push(window)
+		wm.order.Push(window)
+	}
+	wm.sortLayers()
+
+	// interactions with windows of the previous workspace are over
+	wm.draggedWindow = nil
+	wm.snapPreview = SnapNone
+	wm.moveResizeWindow = nil
+	wm.switcherWindows = nil
+	return wm
+}
+
+// MoveToWorkspace moves the given window to the workspace with the given name,
+// on top of its windows. Unknown workspace names are ignored
+func (wm *Manager) MoveToWorkspace(window Window, name string) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	from, to := wm.workspaceOf(window), wm.findWorkspace(name)
+	if from == -1 || to == -1 || from == to {
+		return wm
+	}
+	wm.moveToWorkspace(window, from, to)
+	return wm
+}
+
+// moveToWorkspace moves the given window from one workspace to another, by index
+func (wm *Manager) moveToWorkspace(window Window, from, to int) {
+	if from == wm.activeWorkspace {
+		wm.windows.Remove(window)
+		wm.order.Remove(window)
+		wm.history.Remove(window)
+		if wm.draggedWindow == window {
+			wm.draggedWindow = nil
+		}
+		if wm.moveResizeWindow == window {
+			wm.moveResizeWindow = nil
+		}
+		wm.switcherWindows = nil
+	} else {
+		ws := wm.workspaces[from]
+		ws.windows.Remove(window)
+		ws.order.Remove(window)
+		ws.history.Remove(window)
+	}
+	if to == wm.activeWorkspace {
+		wm.windows.Push(window)
+		wm.order.Push(window)
+		wm.sortLayers()
+	} else {
+		ws := wm.workspaces[to]
+		ws.windows.Push(window)
+		ws.order.Push(window)
+	}
+}
+
+// WorkspaceOf returns the name of the workspace the given window is on,
+// or an empty string if the window is not part of this window manager
+func (wm *Manager) WorkspaceOf(window Window) string {
+	wm.lock()
+	defer wm.unlock()
+	if i := wm.workspaceOf(window); i != -1 {
+		return wm.workspaces[i].name
+	}
+	return ""
+}
+
+// SetSticky sets whether the given window is shown on every workspace.
+// A window made sticky on another workspace comes to the active one
+func (wm *Manager) SetSticky(window Window, sticky bool) *Manager {
+	wm.lock()
+	defer wm.unlock()
+	i := wm.workspaceOf(window)
+	if i == -1 {
+		return wm
+	}
+	if sticky && i != wm.activeWorkspace {
+		wm.moveToWorkspace(window, i, wm.activeWorkspace)
+	}
+	wm.state(window).sticky = sticky
+	return wm
+}
+
+// IsSticky returns true if the given window is shown on every workspace
+func (wm *Manager) IsSticky(window Window) bool {
+	wm.lock()
+	defer wm.unlock()
+	if wm.workspaceOf(window) == -1 {
+		return false
+	}
+	return wm.state(window).sticky
+}
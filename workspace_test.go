@@ -0,0 +1,151 @@
+package winman_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/epiclabs-io/winman"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestWorkspaces(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(40, 20)
+	screen.Init()
+	var focusedPrimitive tview.Primitive
+	setFocus := Focuser(&focusedPrimitive)
+
+	if wm.GetWorkspace() != winman.DefaultWorkspace {
+		t.Fatalf("Expected the default workspace to be active, got %q", wm.GetWorkspace())
+	}
+	wndA := wm.NewWindow().SetID("a").Show()
+	wndB := wm.NewWindow().SetID("b").Show()
+	clock := wm.NewWindow().SetID("clock").Show()
+	wm.SetSticky(clock, true)
+	setFocus(wndA)
+	wm.Draw(screen)
+
+	wm.AddWorkspace("mail").AddWorkspace("mail")
+	if names := wm.Workspaces(); len(names) != 2 || names[1] != "mail" {
+		t.Fatalf("Expected two workspaces, got %v", names)
+	}
+
+	// switching shows only the windows of the workspace, plus sticky ones
+	wm.SwitchWorkspace("mail")
+	if wm.GetWorkspace() != "mail" {
+		t.Fatal("Expected the mail workspace to be active")
+	}
+	if wm.WindowCount() != 1 || wm.Window(0) != clock {
+		t.Fatal("Expected only the sticky window on the new workspace")
+	}
+	wndC := wm.NewWindow().SetID("c").Show()
+	setFocus(wm)
+	if !wndC.HasFocus() {
+		t.Fatal("Expected wndC to get focus")
+	}
+	wm.Draw(screen)
+
+	// windows of inactive workspaces can still be found
+	if wm.WindowByID("b") != wndB || wm.WorkspaceOf(wndB) != winman.DefaultWorkspace {
+		t.Fatal("Expected wndB to be found on the default workspace")
+	}
+
+	// switching back restores the windows, their z order and the focused window
+	wm.SwitchWorkspace(winman.DefaultWorkspace)
+	if wm.WindowCount() != 3 || wm.GetZ(wndB) != 0 || wm.GetZ(wndA) != 1 || wm.GetZ(clock) != 2 {
+		t.Fatal("Expected the default workspace to keep its z order, with the sticky window on top")
+	}
+	setFocus(wm)
+	if !wndA.HasFocus() {
+		t.Fatal("Expected wndA to get focus back, since it had focus on this workspace")
+	}
+
+	// moving windows between workspaces
+	wm.MoveToWorkspace(wndB, "mail")
+	if wm.GetZ(wndB) != -1 || wm.WorkspaceOf(wndB) != "mail" {
+		t.Fatal("Expected wndB to be moved to the mail workspace")
+	}
+	wm.MoveToWorkspace(wndC, winman.DefaultWorkspace)
+	if wm.GetZ(wndC) != 2 {
+		t.Fatal("Expected wndC to be moved on top of the active workspace")
+	}
+	wm.SetSticky(clock, false)
+	if wm.IsSticky(clock) {
+		t.Fatal("Expected the clock to not be sticky anymore")
+	}
+
+	// a window made sticky on an inactive workspace comes to the active one
+	wm.SetSticky(wndB, true)
+	if wm.WorkspaceOf(wndB) != winman.DefaultWorkspace || wm.GetZ(wndB) != 3 || !wm.IsSticky(wndB) {
+		t.Fatal("Expected wndB to be moved on top of the active workspace and be sticky")
+	}
+	wm.MoveToWorkspace(wndB, "mail").SetSticky(wndB, false)
+
+	// windows that are not managed cannot be sticky
+	foreign := winman.NewWindow()
+	wm.SetSticky(foreign, true)
+	if wm.IsSticky(foreign) {
+		t.Fatal("Expected a window that is not managed to not be sticky")
+	}
+
+	// removing a workspace brings its windows to the active workspace
+	wm.RemoveWorkspace("mail")
+	if len(wm.Workspaces()) != 1 || wm.WorkspaceOf(wndB) != winman.DefaultWorkspace {
+		t.Fatal("Expected the mail workspace to be removed and wndB to be moved to the default workspace")
+	}
+	wm.RemoveWorkspace(winman.DefaultWorkspace)
+	if len(wm.Workspaces()) != 1 {
+		t.Fatal("Expected the active workspace to not be removed")
+	}
+}
+
+func TestWorkspaceLayout(t *testing.T) {
+	wm := winman.NewWindowManager()
+	wndA := wm.NewWindow().SetID("a").SetTypeName("form").Show()
+	wndB := wm.NewWindow().SetID("b").SetTypeName("form").Show()
+	wndC := wm.NewWindow().SetID("c").SetTypeName("form").Show()
+	clock := wm.NewWindow().SetID("clock").SetTypeName("form").Show()
+	wm.SetSticky(clock, true)
+	wm.AddWorkspace("mail").MoveToWorkspace(wndB, "mail").MoveToWorkspace(wndC, "mail")
+
+	var buf bytes.Buffer
+	if err := wm.SaveLayout(&buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.String()
+
+	// windows are restored on their workspace, even if it does not exist yet
+	wm2 := winman.NewWindowManager().RegisterWindowType("form", func(id string) winman.Window {
+		return winman.NewWindow()
+	})
+	if err := wm2.LoadLayout(strings.NewReader(saved)); err != nil {
+		t.Fatal(err)
+	}
+	if wm2.WorkspaceOf(wm2.WindowByID("a")) != winman.DefaultWorkspace || wm2.WorkspaceOf(wm2.WindowByID("b")) != "mail" {
+		t.Fatal("Expected the windows to be restored on their workspace")
+	}
+	if !wm2.IsSticky(wm2.WindowByID("clock")) || wm2.WindowCount() != 2 {
+		t.Fatal("Expected the sticky window to be restored on the active workspace")
+	}
+	wm2.SwitchWorkspace("mail")
+	if wm2.Window(0) != wm2.WindowByID("b") || wm2.Window(1) != wm2.WindowByID("c") {
+		t.Fatal("Expected the z order of the inactive workspace to be restored")
+	}
+
+	// existing windows go back to their saved workspace, in their saved z order
+	wm.MoveToWorkspace(wndB, winman.DefaultWorkspace).SetSticky(clock, false)
+	if err := wm.LoadLayout(strings.NewReader(saved)); err != nil {
+		t.Fatal(err)
+	}
+	if wm.WorkspaceOf(wndB) != "mail" || !wm.IsSticky(clock) || wm.WorkspaceOf(wndA) != winman.DefaultWorkspace {
+		t.Fatal("Expected existing windows to go back to their saved workspace")
+	}
+	wm.SwitchWorkspace("mail")
+	if wm.Window(0) != wndB || wm.Window(1) != wndC || wm.Window(2) != clock {
+		t.Fatal("Expected the z order of the workspace to be restored, with the sticky window on top")
+	}
+}